@@ -8,10 +8,13 @@ import (
 	"dube/pkg/program"
 	log "github.com/golang/glog"
 	"google.golang.org/grpc"
+	"math/rand"
+	"time"
 )
 
 type app struct {
 	options *options.Options
+	cat     *cat.Cat
 	grpcSrv *grpc.Server
 	httpSrv *http.Server
 }
@@ -25,14 +28,20 @@ func (c *app) Init() {
 }
 
 func (c *app) Start() {
-	s := cat.New(c.options)
-	c.grpcSrv = rpc.New(c.options.RpcServer, s)
-	c.httpSrv = http.New(c.options.HTTPServer, s)
+	rand.Seed(time.Now().UTC().UnixNano())
+
+	var err error
+	if c.cat, err = cat.New(c.options); err != nil {
+		log.Fatalf("fail to start cat - (%v)", err)
+	}
+	c.grpcSrv = rpc.New(c.options.RpcServer, c.cat)
+	c.httpSrv = http.New(c.options.HTTPServer, c.cat)
 }
 
 func (c *app) Stop() {
 	c.grpcSrv.GracefulStop()
 	c.httpSrv.GracefulStop()
+	c.cat.Close()
 }
 
 func main() {
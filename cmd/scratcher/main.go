@@ -3,13 +3,17 @@ package main
 import (
 	"dube/internal/scratcher"
 	"dube/internal/scratcher/conf"
+	"dube/internal/scratcher/rpc"
 	"dube/pkg/program"
 	log "github.com/golang/glog"
+	"google.golang.org/grpc"
 	"math/rand"
 	"time"
 )
 
 type app struct {
+	srv     *scratcher.Scratcher
+	grpcSrv *grpc.Server
 }
 
 func (a *app) Init() {
@@ -24,12 +28,17 @@ func (a *app) Start() {
 
 	rand.Seed(time.Now().UTC().UnixNano())
 
-	srv := scratcher.New(c)
-	scratcher.StartWebsocket(srv)
+	if a.srv, err = scratcher.New(c); err != nil {
+		log.Fatalf("fail to start scratcher - (%v)", err)
+	}
+	a.grpcSrv = rpc.New(c.RPCServer, a.srv)
+	scratcher.StartWebsocket(a.srv)
 }
 
 func (a *app) Stop() {
-	log.Fatal("stop")
+	a.grpcSrv.GracefulStop()
+	a.srv.Close()
+	log.Flush()
 }
 
 func main() {
@@ -1 +1,37 @@
 package main
+
+import (
+	"dube/internal/wool"
+	"dube/internal/wool/conf"
+	"dube/pkg/program"
+	"dube/pkg/queue"
+	log "github.com/golang/glog"
+)
+
+type app struct {
+	wool *wool.Wool
+}
+
+func (a *app) Init() {
+
+}
+
+func (a *app) Start() {
+	c, err := conf.Default()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	consumer := queue.NewKafkaConsumer(c.Kafka.Brokers, c.Kafka.Topic, c.Kafka.Group)
+	a.wool = wool.New(c, consumer)
+}
+
+func (a *app) Stop() {
+	a.wool.Close()
+	log.Flush()
+}
+
+func main() {
+	program := new(program.Program)
+	program.Run(new(app))
+}
@@ -2,3 +2,5 @@ package dube
 
 //go:generate protoc -I. -I$GOPATH/src --go_out=plugins=grpc:. --go_opt=paths=source_relative internal/protocol/protocol.proto
 //go:generate protoc -I. -I$GOPATH/src --go_out=plugins=grpc:. --go_opt=paths=source_relative internal/protocol/cat/cat.proto
+//go:generate protoc -I. -I$GOPATH/src --go_out=plugins=grpc:. --go_opt=paths=source_relative internal/protocol/scratcher/scratcher.proto
+//go:generate protoc -I. -I$GOPATH/src --go_out=plugins=grpc:. --go_opt=paths=source_relative internal/protocol/wool/wool.proto
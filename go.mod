@@ -4,19 +4,20 @@ go 1.18
 
 require (
 	github.com/BurntSushi/toml v1.1.0
+	github.com/gin-gonic/gin v1.7.7
 	github.com/golang/glog v1.0.0
+	github.com/gomodule/redigo v1.8.8
+	github.com/google/uuid v1.3.0
+	github.com/segmentio/kafka-go v0.3.5
 	google.golang.org/grpc v1.46.2
 	google.golang.org/protobuf v1.28.0
 )
 
 require (
 	github.com/gin-contrib/sse v0.1.0 // indirect
-	github.com/gin-gonic/gin v1.7.7 // indirect
 	github.com/go-playground/locales v0.14.0 // indirect
 	github.com/go-playground/universal-translator v0.18.0 // indirect
 	github.com/go-playground/validator/v10 v10.11.0 // indirect
-	github.com/gomodule/redigo v1.8.8 // indirect
-	github.com/google/uuid v1.3.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/leodido/go-urn v1.2.1 // indirect
 	github.com/mattn/go-isatty v0.0.14 // indirect
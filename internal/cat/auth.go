@@ -0,0 +1,150 @@
+package cat
+
+import (
+	"bytes"
+	"context"
+	"dube/internal/cat/options"
+	"dube/pkg/jwt"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	AuthPlain   = "plain"
+	AuthJWT     = "jwt"
+	AuthWebhook = "webhook"
+)
+
+var (
+	ErrAuthType   = errors.New("cat: unknown auth type")
+	ErrAuthSecret = errors.New("cat: jwt auth needs a secret")
+	ErrAuthURL    = errors.New("cat: webhook auth needs a url")
+)
+
+// AuthError rejects the token, the reason is sent back to the client
+type AuthError struct {
+	Reason string
+}
+
+func (e *AuthError) Error() string {
+	return "cat: auth rejected - " + e.Reason
+}
+
+// Identity is who the token belongs to, a key is generated if it's empty
+type Identity struct {
+	Mid      int64  `json:"mid"`
+	Key      string `json:"key"`
+	RoomID   string `json:"room_id"`
+	Platform string `json:"platform"`
+}
+
+// Authenticator identifies the token sent by the client, a rejected token is an *AuthError
+type Authenticator interface {
+	Authenticate(ctx context.Context, token []byte) (*Identity, error)
+}
+
+func newAuthenticator(c *options.Auth) (Authenticator, error) {
+	switch c.Type {
+	case AuthPlain:
+		return plainAuth{}, nil
+	case AuthJWT:
+		if c.Secret == "" {
+			return nil, ErrAuthSecret
+		}
+		return &jwtAuth{secret: []byte(c.Secret), audience: c.Audience, leeway: time.Duration(c.Leeway)}, nil
+	case AuthWebhook:
+		if c.URL == "" {
+			return nil, ErrAuthURL
+		}
+		return &webhookAuth{url: c.URL, client: &http.Client{Timeout: time.Duration(c.Timeout)}}, nil
+	}
+	return nil, ErrAuthType
+}
+
+// plainAuth trusts the identity json sent by the client, for development only
+type plainAuth struct{}
+
+func (plainAuth) Authenticate(ctx context.Context, token []byte) (*Identity, error) {
+	id := new(Identity)
+	if err := json.Unmarshal(token, id); err != nil {
+		return nil, &AuthError{Reason: "malformed token"}
+	}
+	return id, nil
+}
+
+type jwtClaims struct {
+	jwt.Claims
+	Identity
+}
+
+// jwtAuth verifies the HS256 token signed by our backend
+type jwtAuth struct {
+	secret   []byte
+	audience string
+	leeway   time.Duration
+}
+
+func (a *jwtAuth) Authenticate(ctx context.Context, token []byte) (*Identity, error) {
+	c := new(jwtClaims)
+	if err := jwt.Parse(bytes.TrimSpace(token), a.secret, c); err != nil {
+		return nil, &AuthError{Reason: err.Error()}
+	}
+	if err := c.Valid(time.Now(), a.audience, a.leeway); err != nil {
+		return nil, &AuthError{Reason: err.Error()}
+	}
+	return &c.Identity, nil
+}
+
+// webhookAuth posts {"token": token} to our backend, which answers 200 with the identity
+// or 401/403 with {"reason": reason}
+type webhookAuth struct {
+	url    string
+	client *http.Client
+}
+
+func (a *webhookAuth) Authenticate(ctx context.Context, token []byte) (*Identity, error) {
+	body, err := json.Marshal(map[string]string{"token": string(token)})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		id := new(Identity)
+		if err = json.Unmarshal(b, id); err != nil {
+			return nil, err
+		}
+		return id, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		var r struct {
+			Reason string `json:"reason"`
+		}
+		if json.Unmarshal(b, &r); r.Reason == "" {
+			r.Reason = http.StatusText(resp.StatusCode)
+		}
+		return nil, &AuthError{Reason: r.Reason}
+	}
+	return nil, fmt.Errorf("cat: auth webhook status %d", resp.StatusCode)
+}
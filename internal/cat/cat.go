@@ -4,26 +4,77 @@ import (
 	"context"
 	"dube/internal/cat/dao"
 	"dube/internal/cat/options"
-	"encoding/json"
+	"dube/pkg/queue"
+	"dube/pkg/registry"
 	log "github.com/golang/glog"
 	"github.com/google/uuid"
+	"sync"
+	"time"
 )
 
 type Cat struct {
-	dao  *dao.Dao
-	node *options.Node
+	c          *options.Options
+	dao        *dao.Dao
+	auth       Authenticator
+	node       *options.Node
+	registry   registry.Registry
+	lock       sync.RWMutex
+	scratchers map[string]*Scratcher
+	nodes      []*registry.Node
+	lookup     RegionLookup
+	publisher  queue.Publisher
+	onlineLock sync.RWMutex
+	roomCount  map[string]int32
+	closed     chan struct{}
+	cancel     context.CancelFunc
+	procs      sync.WaitGroup
 }
 
-func New(c *options.Options) *Cat {
-	return &Cat{
-		dao.New(c.Redis),
-		c.Node,
+func New(c *options.Options) (*Cat, error) {
+	auth, err := newAuthenticator(c.Auth)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (c *Cat) PutKeys(op int32, keys []string, data []byte) error {
+	cat := &Cat{
+		auth:       auth,
+		c:          c,
+		dao:        dao.New(c.Redis),
+		node:       c.Node,
+		scratchers: newScratchers(c.Scratcher),
+		roomCount:  make(map[string]int32),
+		closed:     make(chan struct{}),
+		lookup:     NewCIDRLookup(c.Node.Regions),
+	}
+	cat.publisher = cat.newPublisher(c)
 
-	return nil
+	if cat.registry = newRegistry(c, registry.AppScratcher); cat.registry != nil {
+		var ctx context.Context
+		ctx, cat.cancel = context.WithCancel(context.Background())
+		go cat.watchScratchers(registry.Watch(ctx, cat.registry, time.Duration(c.Registry.Interval)))
+	}
+	if r := newRegistry(c, registry.AppCat); r != nil && c.Registry.RPCAddr != "" {
+		cat.procs.Add(1)
+		go cat.registerproc(r)
+	}
+	go cat.onlineproc()
+	return cat, nil
+}
+
+func (c *Cat) Close() {
+	close(c.closed)
+	c.procs.Wait()
+	if c.registry != nil {
+		c.cancel()
+		c.registry.Close()
+	}
+	if c.publisher != nil {
+		c.publisher.Close()
+	}
+	for _, s := range c.allScratchers() {
+		s.Close()
+	}
+	c.dao.Close()
 }
 
 func (c *Cat) Heartbeat(ctx context.Context, mid int64, key, server string) error {
@@ -35,23 +86,38 @@ func (c *Cat) Heartbeat(ctx context.Context, mid int64, key, server string) erro
 	return nil
 }
 
-func (c *Cat) Identify(ctx context.Context, server string, token []byte) (mid int64, key, roomID string, hb int64, err error) {
-	var p struct {
-		Mid      int64  `json:"Mid"`
-		Key      string `json:"Key"`
-		RoomID   string `json:"room_id"`
-		Platform string `json:"Platform"`
+// Disconnect removes the mapping of the key on server, has reports whether the key was mapped there
+func (c *Cat) Disconnect(ctx context.Context, mid int64, key, server string) (has bool, err error) {
+	if has, err = c.dao.DelMapping(mid, key, server); err != nil {
+		log.Errorf("disconnect mid(%d) key(%s) server(%s) error - (%v)", mid, key, server, err)
+		return
 	}
+	log.Infof("disconnect mid(%d) key(%s) server(%s)", mid, key, server)
+	return
+}
 
-	if err = json.Unmarshal(token, &p); err != nil {
-		log.Errorf("json.Unmarshal(%s) error - (%v)", err)
+// ChangeRoom records the online of the rooms the key left and joined on server
+func (c *Cat) ChangeRoom(ctx context.Context, mid int64, key, server, roomID string, online map[string]int32) error {
+	if err := c.dao.UpdateServerOnline(server, online); err != nil {
+		log.Errorf("change room(%s) mid(%d) key(%s) server(%s) error - (%v)", roomID, mid, key, server, err)
+		return err
+	}
+	return nil
+}
+
+// Identify authenticates the token of the client connected to server, a rejected token is an *AuthError
+func (c *Cat) Identify(ctx context.Context, server string, token []byte) (mid int64, key, roomID string, hb int64, err error) {
+	id, err := c.auth.Authenticate(ctx, token)
+	if err != nil {
+		log.Errorf("identify server(%s) error - (%v)", server, err)
 		return
 	}
 
-	mid = p.Mid
-	key = p.Key
-	roomID = p.RoomID
-	hb = int64(c.node.Heartbeat)
+	mid = id.Mid
+	key = id.Key
+	roomID = id.RoomID
+	// the scratcher evicts the client after hb, leave room for HeartbeatMax intervals
+	hb = int64(c.node.Heartbeat) * int64(c.node.HeartbeatMax)
 
 	if key == "" {
 		key = uuid.New().String()
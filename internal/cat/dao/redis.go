@@ -9,8 +9,9 @@ import (
 )
 
 type Dao struct {
-	redis       *redis.Pool
-	redisExpire int
+	redis        *redis.Pool
+	redisExpire  int
+	onlineExpire int
 }
 
 func New(c *options.Redis) *Dao {
@@ -18,6 +19,9 @@ func New(c *options.Redis) *Dao {
 		redis: NewRedis(c),
 	}
 	d.redisExpire = int(time.Duration(c.Expire) / time.Second)
+	if d.onlineExpire = int(time.Duration(c.OnlineExpire) / time.Second); d.onlineExpire <= 0 {
+		d.onlineExpire = d.redisExpire
+	}
 	return d
 }
 
@@ -55,10 +59,54 @@ func KeyKeyServer(key string) string {
 	return fmt.Sprintf("key:%s", key)
 }
 
-func (d *Dao) serversByKeys(keys []string) {
+func KeyServerOnline(server string) string {
+	return fmt.Sprintf("online:%s", server)
+}
+
+// ServersByKeys returns the server of every key, "" if the key is not mapped
+func (d *Dao) ServersByKeys(keys []string) ([]string, error) {
 	r := d.redis.Get()
 	defer r.Close()
 
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, KeyKeyServer(key))
+	}
+
+	servers, err := redis.Strings(r.Do("MGET", args...))
+	if err != nil {
+		log.Errorf("redis MGET(%v) error - (%v)", keys, err)
+		return nil, err
+	}
+	return servers, nil
+}
+
+// KeysByMids returns the key -> server mapping of every mid
+func (d *Dao) KeysByMids(mids []int64) ([]map[string]string, error) {
+	r := d.redis.Get()
+	defer r.Close()
+
+	for _, mid := range mids {
+		if err := r.Send("HGETALL", KeyMidServer(mid)); err != nil {
+			log.Errorf("redis send HGETALL(%s) error - (%v)", KeyMidServer(mid), err)
+			return nil, err
+		}
+	}
+
+	if err := r.Flush(); err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]string, len(mids))
+	for i := range mids {
+		keys, err := redis.StringMap(r.Receive())
+		if err != nil {
+			log.Errorf("redis Receive error - (%v)", err)
+			return nil, err
+		}
+		res[i] = keys
+	}
+	return res, nil
 }
 
 func (d *Dao) ExpireMapping(mid int64, key string) (bool, error) {
@@ -104,7 +152,7 @@ func (d *Dao) AddMapping(mid int64, key, server string) error {
 		}
 
 		if err := r.Send("EXPIRE", KeyMidServer(mid), d.redisExpire); err != nil {
-			log.Errorf("redis send EXPIRE(%s,%d) error - (%v)", KeyMidServer(mid), d.redisExpire)
+			log.Errorf("redis send EXPIRE(%s,%d) error - (%v)", KeyMidServer(mid), d.redisExpire, err)
 			return err
 		}
 		n += 2
@@ -133,3 +181,141 @@ func (d *Dao) AddMapping(mid int64, key, server string) error {
 
 	return nil
 }
+
+// delMappingScript deletes the key mapping and the key of the mid hash (KEYS[2], optional)
+// only while they still point to the server ARGV[2], it returns the count deleted
+var delMappingScript = redis.NewScript(-1, `
+local n = 0
+if redis.call("GET", KEYS[1]) == ARGV[2] then
+	n = n + redis.call("DEL", KEYS[1])
+end
+if KEYS[2] and redis.call("HGET", KEYS[2], ARGV[1]) == ARGV[2] then
+	n = n + redis.call("HDEL", KEYS[2], ARGV[1])
+end
+return n
+`)
+
+// DelMapping removes the key from the mid hash and the key mapping if they are still on server,
+// so a key reconnected to another server keeps its mapping, has reports whether the key was mapped
+func (d *Dao) DelMapping(mid int64, key, server string) (has bool, err error) {
+	r := d.redis.Get()
+	defer r.Close()
+
+	args := []interface{}{1, KeyKeyServer(key)}
+	if mid > 0 {
+		args = []interface{}{2, KeyKeyServer(key), KeyMidServer(mid)}
+	}
+	args = append(args, key, server)
+
+	n, err := redis.Int(delMappingScript.Do(r, args...))
+	if err != nil {
+		log.Errorf("redis del mapping mid(%d) key(%s) server(%s) error - (%v)", mid, key, server, err)
+		return
+	}
+	return n > 0, nil
+}
+
+// UpdateServerOnline sets the online of the rooms on server, rooms without online are removed
+func (d *Dao) UpdateServerOnline(server string, online map[string]int32) error {
+	r := d.redis.Get()
+	defer r.Close()
+
+	n := 1
+	for room, count := range online {
+		if count > 0 {
+			if err := r.Send("HSET", KeyServerOnline(server), room, count); err != nil {
+				log.Errorf("redis send HSET(%s,%s,%d) error - (%v)", KeyServerOnline(server), room, count, err)
+				return err
+			}
+		} else {
+			if err := r.Send("HDEL", KeyServerOnline(server), room); err != nil {
+				log.Errorf("redis send HDEL(%s,%s) error - (%v)", KeyServerOnline(server), room, err)
+				return err
+			}
+		}
+		n++
+	}
+
+	if err := r.Send("EXPIRE", KeyServerOnline(server), d.onlineExpire); err != nil {
+		log.Errorf("redis send EXPIRE(%s,%d) error - (%v)", KeyServerOnline(server), d.onlineExpire, err)
+		return err
+	}
+
+	if err := r.Flush(); err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if _, err := r.Receive(); err != nil {
+			log.Errorf("redis Receive error - (%v)", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// RenewServerOnline atomically replaces the online of the rooms on server
+func (d *Dao) RenewServerOnline(server string, online map[string]int32) error {
+	r := d.redis.Get()
+	defer r.Close()
+
+	if err := r.Send("MULTI"); err != nil {
+		return err
+	}
+
+	if err := r.Send("DEL", KeyServerOnline(server)); err != nil {
+		log.Errorf("redis send DEL(%s) error - (%v)", KeyServerOnline(server), err)
+		return err
+	}
+
+	if len(online) > 0 {
+		args := make([]interface{}, 0, 1+len(online)*2)
+		args = append(args, KeyServerOnline(server))
+		for room, count := range online {
+			args = append(args, room, count)
+		}
+		if err := r.Send("HSET", args...); err != nil {
+			log.Errorf("redis send HSET(%s) error - (%v)", KeyServerOnline(server), err)
+			return err
+		}
+
+		if err := r.Send("EXPIRE", KeyServerOnline(server), d.onlineExpire); err != nil {
+			log.Errorf("redis send EXPIRE(%s,%d) error - (%v)", KeyServerOnline(server), d.onlineExpire, err)
+			return err
+		}
+	}
+
+	if _, err := r.Do("EXEC"); err != nil {
+		log.Errorf("redis EXEC renew online(%s) error - (%v)", server, err)
+		return err
+	}
+	return nil
+}
+
+// ServersOnline returns the online of the rooms on every server
+func (d *Dao) ServersOnline(servers []string) ([]map[string]int, error) {
+	r := d.redis.Get()
+	defer r.Close()
+
+	for _, server := range servers {
+		if err := r.Send("HGETALL", KeyServerOnline(server)); err != nil {
+			log.Errorf("redis send HGETALL(%s) error - (%v)", KeyServerOnline(server), err)
+			return nil, err
+		}
+	}
+
+	if err := r.Flush(); err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]int, len(servers))
+	for i := range servers {
+		online, err := redis.IntMap(r.Receive())
+		if err != nil {
+			log.Errorf("redis Receive error - (%v)", err)
+			return nil, err
+		}
+		res[i] = online
+	}
+	return res, nil
+}
@@ -23,9 +23,199 @@ func (s *Server) pushKeys(c *gin.Context) {
 		return
 	}
 
-	if err = s.cat.PutKeys(args.Op, args.Keys, msg); err != nil {
+	res, err := s.cat.PutKeys(c, args.Op, args.Keys, msg)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) pushMids(c *gin.Context) {
+
+	var args struct {
+		Op   int32   `form:"op" binding:"required"`
+		Mids []int64 `form:"mids" binding:"required"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	msg, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.PushMids(c, args.Op, args.Mids, msg)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) pushRoom(c *gin.Context) {
+
+	var args struct {
+		Op   int32  `form:"op" binding:"required"`
+		Type string `form:"type" binding:"required"`
+		Room string `form:"room" binding:"required"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	msg, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.PushRoom(c, args.Op, args.Type, args.Room, msg)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) pushAll(c *gin.Context) {
+
+	var args struct {
+		Op    int32 `form:"op" binding:"required"`
+		Speed int32 `form:"speed" binding:"min=0"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	msg, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.PushAll(c, args.Op, args.Speed, msg)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) kick(c *gin.Context) {
+
+	var args struct {
+		Mids   []int64  `form:"mids" binding:"required_without=Keys"`
+		Keys   []string `form:"keys" binding:"required_without=Mids"`
+		Reason string   `form:"reason"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.Kick(c, args.Mids, args.Keys, args.Reason)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) onlineMids(c *gin.Context) {
+
+	var args struct {
+		Mids []int64 `form:"mids" binding:"required"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
 		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.OnlineMids(c, args.Mids)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) onlineKeys(c *gin.Context) {
+
+	var args struct {
+		Keys []string `form:"keys" binding:"required"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	res, err := s.cat.OnlineKeys(c, args.Keys)
+	if err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, res, OK)
+}
+
+func (s *Server) onlineTop(c *gin.Context) {
+
+	var args struct {
+		Type  string `form:"type"`
+		Limit int    `form:"limit" binding:"min=0"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, s.cat.OnlineTop(c, args.Type, args.Limit), OK)
+}
+
+func (s *Server) onlineRoom(c *gin.Context) {
+
+	var args struct {
+		Type  string   `form:"type"`
+		Rooms []string `form:"rooms" binding:"required"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
+	}
+
+	Success(c, s.cat.OnlineRoom(c, args.Type, args.Rooms), OK)
+}
+
+func (s *Server) nodes(c *gin.Context) {
+
+	var args struct {
+		Platform string `form:"platform"`
+	}
+
+	if err := c.BindQuery(&args); err != nil {
+		Error(c, ErrRequest, err.Error())
+		return
 	}
 
-	Success(c, nil, OK)
+	Success(c, s.cat.Nodes(c, args.Platform, c.ClientIP()), OK)
 }
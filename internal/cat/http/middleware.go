@@ -26,7 +26,7 @@ func loggerHandler(c *gin.Context) {
 		path = path + "?" + raw
 	}
 
-	log.Infof("method: %s, path: %s, code: %s, ip: %s, time: %s", method, path, code, ip, latency/time.Millisecond)
+	log.Infof("method: %s, path: %s, code: %d, ip: %s, time: %s", method, path, code, ip, latency/time.Millisecond)
 }
 
 func recoverHandler(c *gin.Context) {
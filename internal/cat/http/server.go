@@ -35,6 +35,15 @@ func New(c *options.HTTPServer, cat *cat.Cat) *Server {
 func (s *Server) initRouter() {
 	g := s.engine.Group("/dubeim")
 	g.POST("/push/keys", s.pushKeys)
+	g.POST("/push/mids", s.pushMids)
+	g.POST("/push/room", s.pushRoom)
+	g.POST("/push/all", s.pushAll)
+	g.POST("/kick", s.kick)
+	g.GET("/online/mid", s.onlineMids)
+	g.GET("/online/key", s.onlineKeys)
+	g.GET("/online/top", s.onlineTop)
+	g.GET("/online/room", s.onlineRoom)
+	g.GET("/nodes", s.nodes)
 }
 
 func (s *Server) GracefulStop() {
@@ -0,0 +1,93 @@
+package cat
+
+import (
+	"context"
+)
+
+const (
+	KickKicked = "kicked"
+)
+
+// Kick disconnects every connected key of the mids and the keys with the reason,
+// the owning scratchers tell the clients the reason before closing them
+func (c *Cat) Kick(ctx context.Context, mids []int64, keys []string, reason string) ([]*KeyResult, error) {
+	var (
+		midKeys []map[string]string
+		servers []string
+		err     error
+	)
+	if len(mids) > 0 {
+		if midKeys, err = c.dao.KeysByMids(mids); err != nil {
+			return nil, err
+		}
+	}
+	if len(keys) > 0 {
+		if servers, err = c.dao.ServersByKeys(keys); err != nil {
+			return nil, err
+		}
+	}
+
+	var res []*KeyResult
+	seen := make(map[string]bool)
+	kickKeys := make(map[string][]string)
+	add := func(key, server string) {
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		res = append(res, &KeyResult{Key: key, Server: server, Status: PushUnknownKey})
+		if server != "" {
+			kickKeys[server] = append(kickKeys[server], key)
+		}
+	}
+	for _, keys := range midKeys {
+		for key, server := range keys {
+			add(key, server)
+		}
+	}
+	for i, key := range keys {
+		add(key, servers[i])
+	}
+
+	status := make(map[string]string, len(res))
+	for server, keys := range kickKeys {
+		for key, s := range c.kickKeys(ctx, server, keys, reason) {
+			status[key] = s
+		}
+	}
+
+	for _, r := range res {
+		if s, ok := status[r.Key]; ok {
+			r.Status = s
+		}
+	}
+	return res, nil
+}
+
+func (c *Cat) kickKeys(ctx context.Context, server string, keys []string, reason string) map[string]string {
+	status := make(map[string]string, len(keys))
+
+	s, err := c.scratcher(server)
+	if err != nil {
+		for _, key := range keys {
+			status[key] = PushUnreachable
+		}
+		return status
+	}
+
+	offline, err := s.Kick(ctx, keys, reason)
+	if err != nil {
+		for _, key := range keys {
+			status[key] = PushUnreachable
+		}
+		return status
+	}
+
+	for _, key := range keys {
+		status[key] = KickKicked
+	}
+	for _, key := range offline {
+		status[key] = PushUnknownKey
+	}
+	return status
+}
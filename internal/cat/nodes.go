@@ -0,0 +1,135 @@
+package cat
+
+import (
+	"context"
+	"dube/internal/cat/options"
+	"dube/pkg/registry"
+	log "github.com/golang/glog"
+	"math"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	PlatformWeb = "web"
+)
+
+// RegionLookup resolves where the client ip is, empty if unknown
+type RegionLookup interface {
+	Lookup(ip string) (region, zone string)
+}
+
+type cidrRegion struct {
+	region string
+	zone   string
+	nets   []*net.IPNet
+}
+
+// CIDRLookup resolves the client ip by the configured networks, the first match wins
+type CIDRLookup []*cidrRegion
+
+func NewCIDRLookup(regions []*options.Region) CIDRLookup {
+	l := make(CIDRLookup, 0, len(regions))
+	for _, r := range regions {
+		cr := &cidrRegion{region: r.Region, zone: r.Zone}
+		for _, cidr := range r.CIDRs {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				log.Errorf("region(%s) bad cidr(%s) - (%v)", r.Region, cidr, err)
+				continue
+			}
+			cr.nets = append(cr.nets, n)
+		}
+		l = append(l, cr)
+	}
+	return l
+}
+
+func (l CIDRLookup) Lookup(ip string) (region, zone string) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return
+	}
+	for _, r := range l {
+		for _, n := range r.nets {
+			if n.Contains(addr) {
+				return r.region, r.zone
+			}
+		}
+	}
+	return
+}
+
+// SetRegionLookup replaces the lookup of the client region
+func (c *Cat) SetRegionLookup(l RegionLookup) {
+	c.lock.Lock()
+	c.lookup = l
+	c.lock.Unlock()
+}
+
+type Nodes struct {
+	Domain    string   `json:"domain"`
+	Heartbeat int64    `json:"heartbeat"` //seconds
+	Region    string   `json:"region,omitempty"`
+	Zone      string   `json:"zone,omitempty"`
+	Addrs     []string `json:"addrs"`
+}
+
+// Nodes returns the scratcher addresses for the client, the nodes of the client zone and region come first
+// and among them nodes are ordered randomly by their weight over their load, so clients spread across the
+// nodes while the online counts are not refreshed yet
+func (c *Cat) Nodes(ctx context.Context, platform, ip string) *Nodes {
+	c.lock.RLock()
+	nodes, lookup := c.nodes, c.lookup
+	c.lock.RUnlock()
+
+	res := &Nodes{
+		Domain:    c.node.Domain,
+		Heartbeat: int64(time.Duration(c.node.Heartbeat) / time.Second),
+		Addrs:     []string{},
+	}
+	if lookup != nil {
+		res.Region, res.Zone = lookup.Lookup(ip)
+	}
+
+	candidates := make([]*registry.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Weight > 0 && len(n.Addrs) > 0 {
+			candidates = append(candidates, n)
+		}
+	}
+
+	affinity := func(n *registry.Node) int {
+		switch {
+		case res.Region == "" || n.Region != res.Region:
+			return 0
+		case res.Zone != "" && n.Zone == res.Zone:
+			return 2
+		}
+		return 1
+	}
+	// weighted random order, the larger rand^(1/w) the earlier
+	keys := make(map[*registry.Node]float64, len(candidates))
+	for _, n := range candidates {
+		keys[n] = math.Pow(rand.Float64(), float64(n.Online+1)/n.Weight)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if x, y := affinity(a), affinity(b); x != y {
+			return x > y
+		}
+		return keys[a] > keys[b]
+	})
+
+	for _, n := range candidates {
+		for _, addr := range n.Addrs {
+			if platform != PlatformWeb || strings.HasPrefix(addr, "ws://") || strings.HasPrefix(addr, "wss://") {
+				res.Addrs = append(res.Addrs, addr)
+			}
+		}
+	}
+	return res
+}
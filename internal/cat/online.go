@@ -0,0 +1,171 @@
+package cat
+
+import (
+	"context"
+	log "github.com/golang/glog"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	onlineRefresh = 10 * time.Second
+)
+
+type Device struct {
+	Key    string `json:"key"`
+	Server string `json:"server"`
+}
+
+type MidOnline struct {
+	Mid     int64     `json:"mid"`
+	Online  bool      `json:"online"`
+	Devices []*Device `json:"devices"`
+}
+
+type KeyOnline struct {
+	Key    string `json:"key"`
+	Online bool   `json:"online"`
+	Server string `json:"server,omitempty"`
+}
+
+type RoomOnline struct {
+	Room  string `json:"room"`
+	Count int32  `json:"count"`
+}
+
+// RenewOnline replaces the online of the rooms on server, returns the online of the rooms on every server
+func (c *Cat) RenewOnline(ctx context.Context, server string, roomCount map[string]int32) (map[string]int32, error) {
+	if err := c.dao.RenewServerOnline(server, roomCount); err != nil {
+		log.Errorf("renew online server(%s) error - (%v)", server, err)
+		return nil, err
+	}
+
+	allRoomCount := make(map[string]int32, len(roomCount))
+	c.onlineLock.RLock()
+	for room := range roomCount {
+		if count, ok := c.roomCount[room]; ok {
+			allRoomCount[room] = count
+		}
+	}
+	c.onlineLock.RUnlock()
+	return allRoomCount, nil
+}
+
+// OnlineTop returns the limit rooms of the type having the most online, every type if typ is ""
+func (c *Cat) OnlineTop(ctx context.Context, typ string, limit int) []*RoomOnline {
+	prefix := EncodeRoomKey(typ, "")
+
+	c.onlineLock.RLock()
+	res := make([]*RoomOnline, 0, len(c.roomCount))
+	for key, count := range c.roomCount {
+		if typ == "" || strings.HasPrefix(key, prefix) {
+			res = append(res, &RoomOnline{Room: key, Count: count})
+		}
+	}
+	c.onlineLock.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Room < res[j].Room
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// OnlineRoom returns the online of the rooms of the type across the scratchers
+func (c *Cat) OnlineRoom(ctx context.Context, typ string, rooms []string) []*RoomOnline {
+	c.onlineLock.RLock()
+	defer c.onlineLock.RUnlock()
+
+	res := make([]*RoomOnline, len(rooms))
+	for i, room := range rooms {
+		key := room
+		if typ != "" {
+			key = EncodeRoomKey(typ, room)
+		}
+		res[i] = &RoomOnline{Room: key, Count: c.roomCount[key]}
+	}
+	return res
+}
+
+// loadOnline aggregates the online of the rooms reported by every scratcher
+func (c *Cat) loadOnline() error {
+	scratchers := c.allScratchers()
+	servers := make([]string, 0, len(scratchers))
+	for server := range scratchers {
+		servers = append(servers, server)
+	}
+
+	online, err := c.dao.ServersOnline(servers)
+	if err != nil {
+		return err
+	}
+
+	roomCount := make(map[string]int32)
+	for _, rooms := range online {
+		for room, count := range rooms {
+			roomCount[room] += int32(count)
+		}
+	}
+
+	c.onlineLock.Lock()
+	c.roomCount = roomCount
+	c.onlineLock.Unlock()
+	return nil
+}
+
+func (c *Cat) onlineproc() {
+	ticker := time.NewTicker(onlineRefresh)
+	defer ticker.Stop()
+
+	for {
+		if err := c.loadOnline(); err != nil {
+			log.Errorf("load online error - (%v)", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// OnlineMids returns the connected keys and their servers of every mid
+func (c *Cat) OnlineMids(ctx context.Context, mids []int64) ([]*MidOnline, error) {
+	midKeys, err := c.dao.KeysByMids(mids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*MidOnline, len(mids))
+	for i, mid := range mids {
+		devices := make([]*Device, 0, len(midKeys[i]))
+		for key, server := range midKeys[i] {
+			devices = append(devices, &Device{Key: key, Server: server})
+		}
+		sort.Slice(devices, func(a, b int) bool {
+			return devices[a].Key < devices[b].Key
+		})
+		res[i] = &MidOnline{Mid: mid, Online: len(devices) > 0, Devices: devices}
+	}
+	return res, nil
+}
+
+// OnlineKeys returns the server every key is connected to
+func (c *Cat) OnlineKeys(ctx context.Context, keys []string) ([]*KeyOnline, error) {
+	servers, err := c.dao.ServersByKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*KeyOnline, len(keys))
+	for i, key := range keys {
+		res[i] = &KeyOnline{Key: key, Online: servers[i] != "", Server: servers[i]}
+	}
+	return res, nil
+}
@@ -14,12 +14,26 @@ type Options struct {
 	Redis      *Redis
 	Node       *Node
 	HTTPServer *HTTPServer
+	Scratcher  *Scratcher
+	Queue      *Queue
+	Kafka      *Kafka
+	Registry   *Registry
+	Auth       *Auth
 }
 
 type Node struct {
-	Domain    string
-	Heartbeat otime.Duration
-	Weight    float64
+	Domain       string
+	Heartbeat    otime.Duration //interval the clients heartbeat at
+	HeartbeatMax int            //missed heartbeats before the scratcher evicts a client
+	Weight       float64
+	Regions      []*Region
+}
+
+// Region is where the clients of the networks are, for allocating them the nearest scratchers
+type Region struct {
+	Region string
+	Zone   string
+	CIDRs  []string
 }
 
 type RpcServer struct {
@@ -40,9 +54,36 @@ type HTTPServer struct {
 	WriteTimeout otime.Duration
 }
 
+type Scratcher struct {
+	Dial    otime.Duration
+	Timeout otime.Duration
+	Servers map[string]string //server id -> rpc addr
+}
+
+type Auth struct {
+	Type     string //"plain" trusts the identity json of the client for development only, "jwt" or "webhook"
+	Secret   string //jwt HS256 secret
+	Audience string //jwt audience, not checked if ""
+	Leeway   otime.Duration
+	URL      string //webhook url
+	Timeout  otime.Duration
+}
+
+type Registry struct {
+	Type     string //"" static scratcher servers only, "redis" watch the scratchers registered in redis
+	Interval otime.Duration
+	TTL      otime.Duration
+	RPCAddr  string //advertised to the scratchers, cat is not registered if ""
+}
+
+type Queue struct {
+	Type string //"" push inline, "memory" in-process queue for single node, "kafka" consumed by wool
+	Size int
+}
+
 type Kafka struct {
-	topic   string
-	brokers []string
+	Topic   string
+	Brokers []string
 }
 
 type Redis struct {
@@ -56,6 +97,7 @@ type Redis struct {
 	WriteTimeout otime.Duration
 	IdleTimeout  otime.Duration
 	Expire       otime.Duration
+	OnlineExpire otime.Duration //room online of a server which stops renewing
 }
 
 type Env struct {
@@ -93,6 +135,9 @@ func InitOptions() (*Options, error) {
 func Default() *Options {
 	return &Options{
 		Env: &Env{Region: region, Zone: zone, Host: host},
+		Node: &Node{
+			HeartbeatMax: 2,
+		},
 		RpcServer: &RpcServer{
 			Network:           "tcp",
 			Addr:              ":3319",
@@ -103,5 +148,21 @@ func Default() *Options {
 			KeepAliveInterval: otime.Duration(time.Second * 60),
 			KeepAliveTimeout:  otime.Duration(time.Second * 20),
 		},
+		Scratcher: &Scratcher{
+			Dial:    otime.Duration(time.Second),
+			Timeout: otime.Duration(time.Second),
+		},
+		Queue: &Queue{
+			Size: 1024,
+		},
+		Kafka: &Kafka{},
+		Auth: &Auth{
+			Type:    "plain",
+			Timeout: otime.Duration(time.Second),
+		},
+		Registry: &Registry{
+			Interval: otime.Duration(time.Second * 5),
+			TTL:      otime.Duration(time.Second * 30),
+		},
 	}
 }
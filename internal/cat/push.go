@@ -0,0 +1,210 @@
+package cat
+
+import (
+	"context"
+	wpb "dube/internal/protocol/wool"
+	"fmt"
+)
+
+const (
+	PushDelivered   = "delivered"
+	PushUnknownKey  = "unknown key"
+	PushUnreachable = "server unreachable"
+	PushAccepted    = "accepted"
+	PushQueued      = "queued"
+	PushFailed      = "publish failed"
+	PushDropped     = "dropped"
+)
+
+type KeyResult struct {
+	Key    string `json:"key"`
+	Server string `json:"server,omitempty"`
+	Status string `json:"status"`
+}
+
+type ServerResult struct {
+	Server string `json:"server"`
+	Count  int32  `json:"count"`
+	Status string `json:"status"`
+}
+
+type MidResult struct {
+	Mid       int64 `json:"mid"`
+	Keys      int   `json:"keys"`
+	Delivered int   `json:"delivered"`
+	Queued    int   `json:"queued"`
+}
+
+// PushMids push message to every connected key of the mids
+func (c *Cat) PushMids(ctx context.Context, op int32, mids []int64, data []byte) ([]*MidResult, error) {
+	midKeys, err := c.dao.KeysByMids(mids)
+	if err != nil {
+		return nil, err
+	}
+
+	pushKeys := make(map[string][]string)
+	for _, keys := range midKeys {
+		for key, server := range keys {
+			pushKeys[server] = append(pushKeys[server], key)
+		}
+	}
+
+	status := make(map[string]string)
+	for server, keys := range pushKeys {
+		for key, s := range c.pushKeys(ctx, server, op, keys, data) {
+			status[key] = s
+		}
+	}
+
+	res := make([]*MidResult, len(mids))
+	for i, mid := range mids {
+		res[i] = &MidResult{Mid: mid, Keys: len(midKeys[i])}
+		for key := range midKeys[i] {
+			switch status[key] {
+			case PushDelivered:
+				res[i].Delivered++
+			case PushQueued:
+				res[i].Queued++
+			}
+		}
+	}
+	return res, nil
+}
+
+// PutKeys push message to the keys through the scratchers they are connected to
+func (c *Cat) PutKeys(ctx context.Context, op int32, keys []string, data []byte) ([]*KeyResult, error) {
+	servers, err := c.dao.ServersByKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*KeyResult, len(keys))
+	pushKeys := make(map[string][]string)
+	for i, key := range keys {
+		res[i] = &KeyResult{Key: key, Server: servers[i], Status: PushUnknownKey}
+		if servers[i] != "" {
+			pushKeys[servers[i]] = append(pushKeys[servers[i]], key)
+		}
+	}
+
+	status := make(map[string]string, len(keys))
+	for server, keys := range pushKeys {
+		for key, s := range c.pushKeys(ctx, server, op, keys, data) {
+			status[key] = s
+		}
+	}
+
+	for _, r := range res {
+		if s, ok := status[r.Key]; ok {
+			r.Status = s
+		}
+	}
+	return res, nil
+}
+
+func (c *Cat) pushKeys(ctx context.Context, server string, op int32, keys []string, data []byte) map[string]string {
+	if c.publisher == nil {
+		return c.deliverKeys(ctx, server, op, keys, data)
+	}
+
+	s := PushQueued
+	if err := c.publish(ctx, server, &wpb.PushMsg{Type: wpb.PushMsg_PUSH, Op: op, Server: server, Keys: keys, Body: data}); err != nil {
+		s = PushFailed
+	}
+
+	status := make(map[string]string, len(keys))
+	for _, key := range keys {
+		status[key] = s
+	}
+	return status
+}
+
+// deliverKeys push message to the keys of the server inline
+func (c *Cat) deliverKeys(ctx context.Context, server string, op int32, keys []string, data []byte) map[string]string {
+	status := make(map[string]string, len(keys))
+
+	s, err := c.scratcher(server)
+	if err != nil {
+		for _, key := range keys {
+			status[key] = PushUnreachable
+		}
+		return status
+	}
+
+	offline, dropped, err := s.PushMsg(ctx, op, keys, data)
+	if err != nil {
+		for _, key := range keys {
+			status[key] = PushUnreachable
+		}
+		return status
+	}
+
+	for _, key := range keys {
+		status[key] = PushDelivered
+	}
+	for _, key := range offline {
+		status[key] = PushUnknownKey
+	}
+	for _, key := range dropped {
+		status[key] = PushDropped
+	}
+	return status
+}
+
+// EncodeRoomKey encode room type and id into the room key used by scratcher, etc: live://1000
+func EncodeRoomKey(typ, room string) string {
+	return fmt.Sprintf("%s://%s", typ, room)
+}
+
+// PushRoom push message to the room on every scratcher, results are empty if the message is queued
+func (c *Cat) PushRoom(ctx context.Context, op int32, typ, room string, data []byte) ([]*ServerResult, error) {
+	roomID := EncodeRoomKey(typ, room)
+
+	if c.publisher != nil {
+		return nil, c.publish(ctx, roomID, &wpb.PushMsg{Type: wpb.PushMsg_ROOM, Op: op, Room: roomID, Body: data})
+	}
+	return c.broadcastRoom(ctx, op, roomID, data), nil
+}
+
+func (c *Cat) broadcastRoom(ctx context.Context, op int32, roomID string, data []byte) []*ServerResult {
+	scratchers := c.allScratchers()
+	res := make([]*ServerResult, 0, len(scratchers))
+	for server, s := range scratchers {
+		r := &ServerResult{Server: server, Status: PushDelivered}
+		count, err := s.BroadcastRoom(ctx, op, roomID, data)
+		if err != nil {
+			r.Status = PushUnreachable
+		}
+		r.Count = count
+		res = append(res, r)
+	}
+	return res
+}
+
+// PushAll push message to every channel on every scratcher, speed is the messages per second of the whole cluster.
+// results are empty if the message is queued
+func (c *Cat) PushAll(ctx context.Context, op, speed int32, data []byte) ([]*ServerResult, error) {
+	if c.publisher != nil {
+		return nil, c.publish(ctx, broadcastKey, &wpb.PushMsg{Type: wpb.PushMsg_BROADCAST, Op: op, Speed: speed, Body: data})
+	}
+	return c.broadcast(ctx, op, speed, data), nil
+}
+
+func (c *Cat) broadcast(ctx context.Context, op, speed int32, data []byte) []*ServerResult {
+	scratchers := c.allScratchers()
+	if n := int32(len(scratchers)); n > 0 && speed > 0 {
+		if speed /= n; speed == 0 {
+			speed = 1
+		}
+	}
+
+	res := make([]*ServerResult, 0, len(scratchers))
+	for server, s := range scratchers {
+		r := &ServerResult{Server: server, Status: PushAccepted}
+		if err := s.Broadcast(ctx, op, speed, data); err != nil {
+			r.Status = PushUnreachable
+		}
+		res = append(res, r)
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package cat
+
+import (
+	"context"
+	"dube/internal/cat/options"
+	wpb "dube/internal/protocol/wool"
+	"dube/pkg/queue"
+	log "github.com/golang/glog"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	QueueMemory = "memory"
+	QueueKafka  = "kafka"
+
+	broadcastKey = "broadcast"
+)
+
+// newPublisher returns nil if pushes are delivered inline
+func (c *Cat) newPublisher(o *options.Options) queue.Publisher {
+	switch o.Queue.Type {
+	case QueueKafka:
+		return queue.NewKafkaPublisher(o.Kafka.Brokers, o.Kafka.Topic)
+	case QueueMemory:
+		m := queue.NewMemory(o.Queue.Size)
+		go c.consume(m)
+		return m
+	}
+	return nil
+}
+
+func (c *Cat) publish(ctx context.Context, key string, m *wpb.PushMsg) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if err = c.publisher.Publish(ctx, []byte(key), b); err != nil {
+		log.Errorf("publish(%s) error - (%v)", key, err)
+		return err
+	}
+	return nil
+}
+
+// consume delivers the messages of the in-process queue
+func (c *Cat) consume(q queue.Consumer) {
+	ctx := context.Background()
+	for {
+		msg, err := q.Consume(ctx)
+		if err != nil {
+			if err == queue.ErrClosed {
+				return
+			}
+			log.Errorf("consume error - (%v)", err)
+			continue
+		}
+
+		m := new(wpb.PushMsg)
+		if err = proto.Unmarshal(msg.Value, m); err != nil {
+			log.Errorf("proto.Unmarshal(%s) error - (%v)", msg.Value, err)
+			continue
+		}
+
+		switch m.GetType() {
+		case wpb.PushMsg_PUSH:
+			c.deliverKeys(ctx, m.GetServer(), m.GetOp(), m.GetKeys(), m.GetBody())
+		case wpb.PushMsg_ROOM:
+			c.broadcastRoom(ctx, m.GetOp(), m.GetRoom(), m.GetBody())
+		case wpb.PushMsg_BROADCAST:
+			c.broadcast(ctx, m.GetOp(), m.GetSpeed(), m.GetBody())
+		}
+	}
+}
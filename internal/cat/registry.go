@@ -0,0 +1,69 @@
+package cat
+
+import (
+	"context"
+	"dube/internal/cat/dao"
+	"dube/internal/cat/options"
+	"dube/pkg/registry"
+	log "github.com/golang/glog"
+	"time"
+)
+
+const (
+	RegistryRedis = "redis"
+
+	DefaultRegistryTTL = 30 * time.Second
+)
+
+// newRegistry returns the registry of the app, nil if only the static scratcher servers are used
+func newRegistry(o *options.Options, app string) registry.Registry {
+	switch o.Registry.Type {
+	case RegistryRedis:
+		ttl := time.Duration(o.Registry.TTL)
+		if ttl <= 0 {
+			ttl = DefaultRegistryTTL
+		}
+		return registry.NewRedis(dao.NewRedis(o.Redis), app, ttl)
+	}
+	return nil
+}
+
+// registerproc renews the cat node three times per ttl until cat is closed,
+// the scratchers discover the cat instances by it
+func (c *Cat) registerproc(r registry.Registry) {
+	defer c.procs.Done()
+
+	ttl := time.Duration(c.c.Registry.TTL)
+	if ttl <= 0 {
+		ttl = DefaultRegistryTTL
+	}
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	n := &registry.Node{
+		Server:  c.c.Env.Host,
+		Region:  c.c.Env.Region,
+		Zone:    c.c.Env.Zone,
+		RPCAddr: c.c.Registry.RPCAddr,
+		Weight:  c.node.Weight,
+	}
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.c.RpcServer.Timeout))
+		if err := r.Register(ctx, n); err != nil {
+			log.Errorf("register cat(%s) error - (%v)", n.Server, err)
+		}
+		cancel()
+
+		select {
+		case <-ticker.C:
+		case <-c.closed:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.c.RpcServer.Timeout))
+			if err := r.Deregister(ctx, n.Server); err != nil {
+				log.Errorf("deregister cat(%s) error - (%v)", n.Server, err)
+			}
+			cancel()
+			r.Close()
+			return
+		}
+	}
+}
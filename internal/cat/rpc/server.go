@@ -5,6 +5,7 @@ import (
 	"dube/internal/cat"
 	"dube/internal/cat/options"
 	pb "dube/internal/protocol/cat"
+	"errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 	"net"
@@ -44,6 +45,10 @@ func New(c *options.RpcServer, cat *cat.Cat) *grpc.Server {
 func (s *Server) Identify(ctx context.Context, req *pb.IdentifyReq) (*pb.IdentifyResp, error) {
 	mid, key, roomID, hb, err := s.srv.Identify(ctx, req.GetServer(), req.GetToken())
 	if err != nil {
+		var reject *cat.AuthError
+		if errors.As(err, &reject) {
+			return &pb.IdentifyResp{Reason: reject.Reason}, nil
+		}
 		return nil, err
 	}
 	resp := new(pb.IdentifyResp)
@@ -60,3 +65,26 @@ func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatReq) (*pb.Heart
 	}
 	return &pb.HeartbeatResp{}, nil
 }
+
+func (s *Server) ChangeRoom(ctx context.Context, req *pb.ChangeRoomReq) (*pb.ChangeRoomResp, error) {
+	if err := s.srv.ChangeRoom(ctx, req.GetMid(), req.GetKey(), req.GetServer(), req.GetRoomID(), req.GetOnline()); err != nil {
+		return nil, err
+	}
+	return &pb.ChangeRoomResp{}, nil
+}
+
+func (s *Server) Disconnect(ctx context.Context, req *pb.DisconnectReq) (*pb.DisconnectResp, error) {
+	has, err := s.srv.Disconnect(ctx, req.GetMid(), req.GetKey(), req.GetServer())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DisconnectResp{Has: has}, nil
+}
+
+func (s *Server) RenewOnline(ctx context.Context, req *pb.RenewOnlineReq) (*pb.RenewOnlineResp, error) {
+	allRoomCount, err := s.srv.RenewOnline(ctx, req.GetServer(), req.GetRoomCount())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RenewOnlineResp{AllRoomCount: allRoomCount}, nil
+}
@@ -0,0 +1,186 @@
+package cat
+
+import (
+	"context"
+	"dube/internal/cat/options"
+	pb "dube/internal/protocol/scratcher"
+	"dube/pkg/registry"
+	"errors"
+	log "github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"time"
+)
+
+const (
+	grpcInitialWindowSize     = 1 << 24
+	grpcInitialConnWindowSize = 1 << 24
+	grpcMaxCallMsgSize        = 1 << 24
+	grpcMaxSendMsgSize        = 1 << 24
+	grpcBackoffMaxDelay       = 3 * time.Second
+	grpcKeepAliveTime         = 10 * time.Second
+	grpcKeepAliveTimeout      = 3 * time.Second
+)
+
+var (
+	ErrScratcherNotFound = errors.New("cat: scratcher server not found")
+)
+
+// Scratcher is the rpc client of one scratcher server
+type Scratcher struct {
+	server  string
+	addr    string
+	client  pb.ScratcherClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+}
+
+func NewScratcher(server, addr string, c *options.Scratcher) (*Scratcher, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Dial))
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		[]grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithInitialWindowSize(grpcInitialWindowSize),
+			grpc.WithInitialConnWindowSize(grpcInitialConnWindowSize),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxCallMsgSize)),
+			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcMaxSendMsgSize)),
+			grpc.WithBackoffMaxDelay(grpcBackoffMaxDelay),
+			grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time:                grpcKeepAliveTime,
+				Timeout:             grpcKeepAliveTimeout,
+				PermitWithoutStream: true,
+			}),
+		}...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scratcher{
+		server:  server,
+		addr:    addr,
+		client:  pb.NewScratcherClient(conn),
+		conn:    conn,
+		timeout: time.Duration(c.Timeout),
+	}, nil
+}
+
+// PushMsg push message to the keys connected to this scratcher, returns offline keys
+// and keys the message is dropped for
+func (s *Scratcher) PushMsg(ctx context.Context, op int32, keys []string, body []byte) (offline, dropped []string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.client.PushMsg(ctx, &pb.PushMsgReq{Keys: keys, Op: op, Body: body})
+	if err != nil {
+		log.Errorf("scratcher(%s) PushMsg(%v) error - (%v)", s.server, keys, err)
+		return nil, nil, err
+	}
+	return resp.GetOffline(), resp.GetDropped(), nil
+}
+
+// BroadcastRoom push message to the room channels of this scratcher, returns the count written
+func (s *Scratcher) BroadcastRoom(ctx context.Context, op int32, roomID string, body []byte) (int32, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.client.BroadcastRoom(ctx, &pb.BroadcastRoomReq{RoomID: roomID, Op: op, Body: body})
+	if err != nil {
+		log.Errorf("scratcher(%s) BroadcastRoom(%s) error - (%v)", s.server, roomID, err)
+		return 0, err
+	}
+	return resp.GetCount(), nil
+}
+
+// Broadcast push message to every channel of this scratcher at most speed messages per second
+func (s *Scratcher) Broadcast(ctx context.Context, op int32, speed int32, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if _, err := s.client.Broadcast(ctx, &pb.BroadcastReq{Op: op, Body: body, Speed: speed}); err != nil {
+		log.Errorf("scratcher(%s) Broadcast() error - (%v)", s.server, err)
+		return err
+	}
+	return nil
+}
+
+// Kick disconnects the keys connected to this scratcher with the reason, returns offline keys
+func (s *Scratcher) Kick(ctx context.Context, keys []string, reason string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.client.Kick(ctx, &pb.KickReq{Keys: keys, Reason: reason})
+	if err != nil {
+		log.Errorf("scratcher(%s) Kick(%v) error - (%v)", s.server, keys, err)
+		return nil, err
+	}
+	return resp.GetOffline(), nil
+}
+
+func (s *Scratcher) Addr() string {
+	return s.addr
+}
+
+func (s *Scratcher) Close() error {
+	return s.conn.Close()
+}
+
+func newScratchers(c *options.Scratcher) map[string]*Scratcher {
+	scratchers := make(map[string]*Scratcher, len(c.Servers))
+	for server, addr := range c.Servers {
+		s, err := NewScratcher(server, addr, c)
+		if err != nil {
+			log.Errorf("fail to dial scratcher(%s) addr(%s) - (%v)", server, addr, err)
+			continue
+		}
+		scratchers[server] = s
+	}
+	return scratchers
+}
+
+func (c *Cat) scratcher(server string) (*Scratcher, error) {
+	s, ok := c.allScratchers()[server]
+	if !ok {
+		return nil, ErrScratcherNotFound
+	}
+	return s, nil
+}
+
+// allScratchers returns the scratchers by server, the map must not be modified
+func (c *Cat) allScratchers() map[string]*Scratcher {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.scratchers
+}
+
+// watchScratchers keeps the scratchers in line with the nodes registered
+func (c *Cat) watchScratchers(ch <-chan []*registry.Node) {
+	for nodes := range ch {
+		c.updateScratchers(nodes)
+	}
+}
+
+// updateScratchers dials the new nodes and closes the scratchers gone, the static servers
+// are kept unless a node registers the same rpc addr
+func (c *Cat) updateScratchers(nodes []*registry.Node) {
+	scratchers, left := registry.Merge(c.allScratchers(), nodes, c.c.Scratcher.Servers, func(server, addr string) (*Scratcher, error) {
+		s, err := NewScratcher(server, addr, c.c.Scratcher)
+		if err != nil {
+			log.Errorf("fail to dial scratcher(%s) addr(%s) - (%v)", server, addr, err)
+			return nil, err
+		}
+		log.Infof("scratcher(%s) addr(%s) joined", server, addr)
+		return s, nil
+	})
+
+	c.lock.Lock()
+	c.scratchers = scratchers
+	c.nodes = nodes
+	c.lock.Unlock()
+
+	for _, s := range left {
+		log.Infof("scratcher(%s) addr(%s) left", s.server, s.addr)
+		s.Close()
+	}
+}
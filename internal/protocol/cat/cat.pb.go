@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.28.0
 // 	protoc        v3.19.4
 // source: internal/protocol/cat/cat.proto
 
@@ -88,6 +88,8 @@ type IdentifyResp struct {
 	Key       string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	RoomID    string `protobuf:"bytes,3,opt,name=roomID,proto3" json:"roomID,omitempty"`
 	Heartbeat int64  `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// why the token is rejected, the other fields are empty if it's set
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
 func (x *IdentifyResp) Reset() {
@@ -150,6 +152,13 @@ func (x *IdentifyResp) GetHeartbeat() int64 {
 	return 0
 }
 
+func (x *IdentifyResp) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type HeartbeatReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -251,6 +260,338 @@ func (*HeartbeatResp) Descriptor() ([]byte, []int) {
 	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{3}
 }
 
+type ChangeRoomReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mid    int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	RoomID string `protobuf:"bytes,4,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// online channels on server of the rooms left and joined
+	Online map[string]int32 `protobuf:"bytes,5,rep,name=online,proto3" json:"online,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *ChangeRoomReq) Reset() {
+	*x = ChangeRoomReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoomReq) ProtoMessage() {}
+
+func (x *ChangeRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoomReq.ProtoReflect.Descriptor instead.
+func (*ChangeRoomReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChangeRoomReq) GetMid() int64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *ChangeRoomReq) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ChangeRoomReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *ChangeRoomReq) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *ChangeRoomReq) GetOnline() map[string]int32 {
+	if x != nil {
+		return x.Online
+	}
+	return nil
+}
+
+type ChangeRoomResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeRoomResp) Reset() {
+	*x = ChangeRoomResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoomResp) ProtoMessage() {}
+
+func (x *ChangeRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoomResp.ProtoReflect.Descriptor instead.
+func (*ChangeRoomResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{5}
+}
+
+type DisconnectReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mid    int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+}
+
+func (x *DisconnectReq) Reset() {
+	*x = DisconnectReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectReq) ProtoMessage() {}
+
+func (x *DisconnectReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectReq.ProtoReflect.Descriptor instead.
+func (*DisconnectReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DisconnectReq) GetMid() int64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *DisconnectReq) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DisconnectReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type DisconnectResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Has bool `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
+}
+
+func (x *DisconnectResp) Reset() {
+	*x = DisconnectResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectResp) ProtoMessage() {}
+
+func (x *DisconnectResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectResp.ProtoReflect.Descriptor instead.
+func (*DisconnectResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DisconnectResp) GetHas() bool {
+	if x != nil {
+		return x.Has
+	}
+	return false
+}
+
+type RenewOnlineReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// online channels on server of every room
+	RoomCount map[string]int32 `protobuf:"bytes,2,rep,name=roomCount,proto3" json:"roomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RenewOnlineReq) Reset() {
+	*x = RenewOnlineReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenewOnlineReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewOnlineReq) ProtoMessage() {}
+
+func (x *RenewOnlineReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewOnlineReq.ProtoReflect.Descriptor instead.
+func (*RenewOnlineReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RenewOnlineReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *RenewOnlineReq) GetRoomCount() map[string]int32 {
+	if x != nil {
+		return x.RoomCount
+	}
+	return nil
+}
+
+type RenewOnlineResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// online channels on every server of the rooms in the request
+	AllRoomCount map[string]int32 `protobuf:"bytes,1,rep,name=allRoomCount,proto3" json:"allRoomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RenewOnlineResp) Reset() {
+	*x = RenewOnlineResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_cat_cat_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenewOnlineResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewOnlineResp) ProtoMessage() {}
+
+func (x *RenewOnlineResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_cat_cat_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewOnlineResp.ProtoReflect.Descriptor instead.
+func (*RenewOnlineResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_cat_cat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RenewOnlineResp) GetAllRoomCount() map[string]int32 {
+	if x != nil {
+		return x.AllRoomCount
+	}
+	return nil
+}
+
 var File_internal_protocol_cat_cat_proto protoreflect.FileDescriptor
 
 var file_internal_protocol_cat_cat_proto_rawDesc = []byte{
@@ -260,31 +601,88 @@ var file_internal_protocol_cat_cat_proto_rawDesc = []byte{
 	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
 	0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x68, 0x0a, 0x0c, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f,
-	0x6f, 0x6d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
-	0x61, 0x74, 0x22, 0x4a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52,
-	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x0f,
-	0x0a, 0x0d, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x32,
-	0x7e, 0x0a, 0x03, 0x63, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x79, 0x12, 0x15, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x64, 0x75, 0x62, 0x65,
-	0x2e, 0x63, 0x61, 0x74, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x12, 0x3c, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x16,
-	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
-	0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61,
-	0x74, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x42,
-	0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69,
-	0x78, 0x75, 0x65, 0x68, 0x61, 0x6e, 0x2f, 0x64, 0x75, 0x62, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x63, 0x61, 0x74, 0x3b, 0x63, 0x61, 0x74, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x0c, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x0f, 0x0a, 0x0d, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0xdb, 0x01, 0x0a, 0x0d, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x3b,
+	0x0a, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x22, 0x4b, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x22, 0x0a, 0x0e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x61, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x68, 0x61, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63,
+	0x61, 0x74, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x71, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3c, 0x0a, 0x0e, 0x52,
+	0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa3, 0x01, 0x0a, 0x0f, 0x52, 0x65,
+	0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4f, 0x0a,
+	0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x52,
+	0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x41,
+	0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3f,
+	0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32,
+	0xc4, 0x02, 0x0a, 0x03, 0x63, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x79, 0x12, 0x15, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x64, 0x75, 0x62,
+	0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x3c, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12,
+	0x16, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63,
+	0x61, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x3f, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x17,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63,
+	0x61, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12,
+	0x17, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e,
+	0x63, 0x61, 0x74, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x42, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x18, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x52, 0x65, 0x6e,
+	0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x64, 0x75,
+	0x62, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x78, 0x75, 0x65, 0x68, 0x61, 0x6e, 0x2f, 0x64, 0x75,
+	0x62, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x63, 0x61, 0x74, 0x3b,
+	0x63, 0x61, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -299,23 +697,41 @@ func file_internal_protocol_cat_cat_proto_rawDescGZIP() []byte {
 	return file_internal_protocol_cat_cat_proto_rawDescData
 }
 
-var file_internal_protocol_cat_cat_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_internal_protocol_cat_cat_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_internal_protocol_cat_cat_proto_goTypes = []interface{}{
-	(*IdentifyReq)(nil),   // 0: dube.cat.IdentifyReq
-	(*IdentifyResp)(nil),  // 1: dube.cat.IdentifyResp
-	(*HeartbeatReq)(nil),  // 2: dube.cat.HeartbeatReq
-	(*HeartbeatResp)(nil), // 3: dube.cat.HeartbeatResp
+	(*IdentifyReq)(nil),     // 0: dube.cat.IdentifyReq
+	(*IdentifyResp)(nil),    // 1: dube.cat.IdentifyResp
+	(*HeartbeatReq)(nil),    // 2: dube.cat.HeartbeatReq
+	(*HeartbeatResp)(nil),   // 3: dube.cat.HeartbeatResp
+	(*ChangeRoomReq)(nil),   // 4: dube.cat.ChangeRoomReq
+	(*ChangeRoomResp)(nil),  // 5: dube.cat.ChangeRoomResp
+	(*DisconnectReq)(nil),   // 6: dube.cat.DisconnectReq
+	(*DisconnectResp)(nil),  // 7: dube.cat.DisconnectResp
+	(*RenewOnlineReq)(nil),  // 8: dube.cat.RenewOnlineReq
+	(*RenewOnlineResp)(nil), // 9: dube.cat.RenewOnlineResp
+	nil,                     // 10: dube.cat.ChangeRoomReq.OnlineEntry
+	nil,                     // 11: dube.cat.RenewOnlineReq.RoomCountEntry
+	nil,                     // 12: dube.cat.RenewOnlineResp.AllRoomCountEntry
 }
 var file_internal_protocol_cat_cat_proto_depIdxs = []int32{
-	0, // 0: dube.cat.cat.Identify:input_type -> dube.cat.IdentifyReq
-	2, // 1: dube.cat.cat.Heartbeat:input_type -> dube.cat.HeartbeatReq
-	1, // 2: dube.cat.cat.Identify:output_type -> dube.cat.IdentifyResp
-	3, // 3: dube.cat.cat.Heartbeat:output_type -> dube.cat.HeartbeatResp
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: dube.cat.ChangeRoomReq.online:type_name -> dube.cat.ChangeRoomReq.OnlineEntry
+	11, // 1: dube.cat.RenewOnlineReq.roomCount:type_name -> dube.cat.RenewOnlineReq.RoomCountEntry
+	12, // 2: dube.cat.RenewOnlineResp.allRoomCount:type_name -> dube.cat.RenewOnlineResp.AllRoomCountEntry
+	0,  // 3: dube.cat.cat.Identify:input_type -> dube.cat.IdentifyReq
+	2,  // 4: dube.cat.cat.Heartbeat:input_type -> dube.cat.HeartbeatReq
+	4,  // 5: dube.cat.cat.ChangeRoom:input_type -> dube.cat.ChangeRoomReq
+	6,  // 6: dube.cat.cat.Disconnect:input_type -> dube.cat.DisconnectReq
+	8,  // 7: dube.cat.cat.RenewOnline:input_type -> dube.cat.RenewOnlineReq
+	1,  // 8: dube.cat.cat.Identify:output_type -> dube.cat.IdentifyResp
+	3,  // 9: dube.cat.cat.Heartbeat:output_type -> dube.cat.HeartbeatResp
+	5,  // 10: dube.cat.cat.ChangeRoom:output_type -> dube.cat.ChangeRoomResp
+	7,  // 11: dube.cat.cat.Disconnect:output_type -> dube.cat.DisconnectResp
+	9,  // 12: dube.cat.cat.RenewOnline:output_type -> dube.cat.RenewOnlineResp
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_internal_protocol_cat_cat_proto_init() }
@@ -372,6 +788,78 @@ func file_internal_protocol_cat_cat_proto_init() {
 				return nil
 			}
 		}
+		file_internal_protocol_cat_cat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeRoomReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_cat_cat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeRoomResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_cat_cat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_cat_cat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_cat_cat_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenewOnlineReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_cat_cat_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenewOnlineResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -379,7 +867,7 @@ func file_internal_protocol_cat_cat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_protocol_cat_cat_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -407,6 +895,9 @@ const _ = grpc.SupportPackageIsVersion6
 type CatClient interface {
 	Identify(ctx context.Context, in *IdentifyReq, opts ...grpc.CallOption) (*IdentifyResp, error)
 	Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatResp, error)
+	ChangeRoom(ctx context.Context, in *ChangeRoomReq, opts ...grpc.CallOption) (*ChangeRoomResp, error)
+	Disconnect(ctx context.Context, in *DisconnectReq, opts ...grpc.CallOption) (*DisconnectResp, error)
+	RenewOnline(ctx context.Context, in *RenewOnlineReq, opts ...grpc.CallOption) (*RenewOnlineResp, error)
 }
 
 type catClient struct {
@@ -435,10 +926,40 @@ func (c *catClient) Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grp
 	return out, nil
 }
 
+func (c *catClient) ChangeRoom(ctx context.Context, in *ChangeRoomReq, opts ...grpc.CallOption) (*ChangeRoomResp, error) {
+	out := new(ChangeRoomResp)
+	err := c.cc.Invoke(ctx, "/dube.cat.cat/ChangeRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catClient) Disconnect(ctx context.Context, in *DisconnectReq, opts ...grpc.CallOption) (*DisconnectResp, error) {
+	out := new(DisconnectResp)
+	err := c.cc.Invoke(ctx, "/dube.cat.cat/Disconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catClient) RenewOnline(ctx context.Context, in *RenewOnlineReq, opts ...grpc.CallOption) (*RenewOnlineResp, error) {
+	out := new(RenewOnlineResp)
+	err := c.cc.Invoke(ctx, "/dube.cat.cat/RenewOnline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatServer is the server API for Cat service.
 type CatServer interface {
 	Identify(context.Context, *IdentifyReq) (*IdentifyResp, error)
 	Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatResp, error)
+	ChangeRoom(context.Context, *ChangeRoomReq) (*ChangeRoomResp, error)
+	Disconnect(context.Context, *DisconnectReq) (*DisconnectResp, error)
+	RenewOnline(context.Context, *RenewOnlineReq) (*RenewOnlineResp, error)
 }
 
 // UnimplementedCatServer can be embedded to have forward compatible implementations.
@@ -451,6 +972,15 @@ func (*UnimplementedCatServer) Identify(context.Context, *IdentifyReq) (*Identif
 func (*UnimplementedCatServer) Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
 }
+func (*UnimplementedCatServer) ChangeRoom(context.Context, *ChangeRoomReq) (*ChangeRoomResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeRoom not implemented")
+}
+func (*UnimplementedCatServer) Disconnect(context.Context, *DisconnectReq) (*DisconnectResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disconnect not implemented")
+}
+func (*UnimplementedCatServer) RenewOnline(context.Context, *RenewOnlineReq) (*RenewOnlineResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewOnline not implemented")
+}
 
 func RegisterCatServer(s *grpc.Server, srv CatServer) {
 	s.RegisterService(&_Cat_serviceDesc, srv)
@@ -492,6 +1022,60 @@ func _Cat_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cat_ChangeRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServer).ChangeRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.cat.cat/ChangeRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServer).ChangeRoom(ctx, req.(*ChangeRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cat_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.cat.cat/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServer).Disconnect(ctx, req.(*DisconnectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cat_RenewOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewOnlineReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServer).RenewOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.cat.cat/RenewOnline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServer).RenewOnline(ctx, req.(*RenewOnlineReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cat_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dube.cat.cat",
 	HandlerType: (*CatServer)(nil),
@@ -504,6 +1088,18 @@ var _Cat_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Heartbeat",
 			Handler:    _Cat_Heartbeat_Handler,
 		},
+		{
+			MethodName: "ChangeRoom",
+			Handler:    _Cat_ChangeRoom_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _Cat_Disconnect_Handler,
+		},
+		{
+			MethodName: "RenewOnline",
+			Handler:    _Cat_RenewOnline_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/protocol/cat/cat.proto",
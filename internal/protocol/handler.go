@@ -0,0 +1,126 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownOp = errors.New("proto: unknown operation")
+)
+
+// Session is the client connection the operations are executed on
+type Session interface {
+	Heartbeat() error
+	Online() int32
+	ChangeRoom(roomID string) error
+	Sub(roomIDs []string) error
+	Unsub(roomIDs []string) error
+	Watch(ops []int32)
+	Unwatch(ops []int32)
+}
+
+// Handler executes the operation on the session, returns true if p is turned into a reply for the client.
+// A bad request is answered by an error reply, an error is returned only if the session fails and closes the conn
+type Handler func(p *Protocol, s Session) (bool, error)
+
+var handlers = make(map[int32]Handler)
+
+// Register makes the handler execute the op, it panics if the op is registered twice
+func Register(op int32, h Handler) {
+	if h == nil {
+		panic("proto: register nil handler")
+	}
+	if _, dup := handlers[op]; dup {
+		panic(fmt.Sprintf("proto: register handler twice for op %d", op))
+	}
+	handlers[op] = h
+}
+
+func init() {
+	Register(OpHeartbeat, heartbeat)
+	Register(OpSendMsg, sendMsg)
+	Register(OpChangeRoom, changeRoom)
+	Register(OpSub, sub)
+	Register(OpUnsub, unsub)
+	Register(OpWatch, watch)
+	Register(OpUnwatch, unwatch)
+}
+
+// Exec executes the operation by its registered handler, unknown ops are turned into an error reply
+func (p *Protocol) Exec(s Session) (bool, error) {
+	h, ok := handlers[p.Op]
+	if !ok {
+		return errorReply(p, ErrUnknownOp)
+	}
+	return h(p, s)
+}
+
+// errorReply turns p into the error reply of the bad request
+func errorReply(p *Protocol, err error) (bool, error) {
+	p.Op = OpErrorReply
+	p.Body = []byte(err.Error())
+	return true, nil
+}
+
+// heartbeat replies the big endian int32 online count of the session room
+func heartbeat(p *Protocol, s Session) (bool, error) {
+	if err := s.Heartbeat(); err != nil {
+		return false, err
+	}
+	p.Op = OpHeartbeatReply
+	p.Body = make([]byte, 4)
+	binary.BigEndian.PutUint32(p.Body, uint32(s.Online()))
+	return true, nil
+}
+
+// sendMsg echoes the message back to the client
+func sendMsg(p *Protocol, s Session) (bool, error) {
+	p.Op = OpSendMsgReply
+	return true, nil
+}
+
+func changeRoom(p *Protocol, s Session) (bool, error) {
+	if err := s.ChangeRoom(string(p.Body)); err != nil {
+		return false, err
+	}
+	p.Op = OpChangeRoomReply
+	return true, nil
+}
+
+func sub(p *Protocol, s Session) (bool, error) {
+	if err := s.Sub(splitRooms(p.Body)); err != nil {
+		return false, err
+	}
+	p.Op = OpSubReply
+	return true, nil
+}
+
+func unsub(p *Protocol, s Session) (bool, error) {
+	if err := s.Unsub(splitRooms(p.Body)); err != nil {
+		return false, err
+	}
+	p.Op = OpUnsubReply
+	return true, nil
+}
+
+func watch(p *Protocol, s Session) (bool, error) {
+	ops, err := decodeOps(p.Body)
+	if err != nil {
+		return errorReply(p, err)
+	}
+	s.Watch(ops)
+	p.Op = OpWatchReply
+	return true, nil
+}
+
+func unwatch(p *Protocol, s Session) (bool, error) {
+	ops, err := decodeOps(p.Body)
+	if err != nil {
+		return errorReply(p, err)
+	}
+	s.Unwatch(ops)
+	p.Op = OpUnwatchReply
+	return true, nil
+}
@@ -0,0 +1,47 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+type testSession struct {
+	watched []int32
+}
+
+func (s *testSession) Heartbeat() error               { return nil }
+func (s *testSession) Online() int32                  { return 3 }
+func (s *testSession) ChangeRoom(roomID string) error { return nil }
+func (s *testSession) Sub(roomIDs []string) error     { return nil }
+func (s *testSession) Unsub(roomIDs []string) error   { return nil }
+func (s *testSession) Watch(ops []int32)              { s.watched = append(s.watched, ops...) }
+func (s *testSession) Unwatch(ops []int32)            {}
+
+func TestExec(t *testing.T) {
+	s := new(testSession)
+
+	p := &Protocol{&Proto{Op: OpHeartbeat}}
+	if reply, err := p.Exec(s); err != nil || !reply || p.Op != OpHeartbeatReply || binary.BigEndian.Uint32(p.Body) != 3 {
+		t.Fatalf("heartbeat reply(%t) op(%d) body(%v) - (%v)", reply, p.Op, p.Body, err)
+	}
+
+	p = &Protocol{&Proto{Op: OpWatch, Body: []byte{0, 0, 3, 232}}}
+	if reply, err := p.Exec(s); err != nil || !reply || p.Op != OpWatchReply || len(s.watched) != 1 || s.watched[0] != 1000 {
+		t.Fatalf("watch reply(%t) op(%d) watched(%v) - (%v)", reply, p.Op, s.watched, err)
+	}
+
+	for _, c := range []struct {
+		op   int32
+		body []byte
+		err  error
+	}{
+		{OpWatch, []byte{0, 0, 3}, ErrOpsLen},
+		{OpUnwatch, []byte{1}, ErrOpsLen},
+		{1 << 20, nil, ErrUnknownOp},
+	} {
+		p = &Protocol{&Proto{Op: c.op, Body: c.body}}
+		if reply, err := p.Exec(s); err != nil || !reply || p.Op != OpErrorReply || string(p.Body) != c.err.Error() {
+			t.Fatalf("op(%d) reply(%t) op(%d) body(%s) - (%v)", c.op, reply, p.Op, p.Body, err)
+		}
+	}
+}
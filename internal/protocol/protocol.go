@@ -1,17 +1,45 @@
 package protocol
 
 import (
-	"context"
-	"dube/internal/protocol/cat"
-	"dube/internal/scratcher"
 	"dube/pkg/websocket"
 	"encoding/binary"
 	"errors"
+	"strings"
 )
 
 const (
-	OpHeartbeat = 1
-	OpAuthReply = 8
+	Version = 1
+)
+
+const (
+	OpHeartbeat       = 1
+	OpHeartbeatReply  = 3
+	OpSendMsg         = 4
+	OpSendMsgReply    = 5
+	OpDisconnectReply = 6
+	OpAuth            = 7
+	OpAuthReply       = 8
+	OpRaw             = 9
+	OpProtoReady      = 10
+	OpProtoFinish     = 11
+	OpChangeRoom      = 12
+	OpChangeRoomReply = 13
+	OpSub             = 14
+	OpSubReply        = 15
+	OpUnsub           = 16
+	OpUnsubReply      = 17
+	OpWatch           = 18
+	OpWatchReply      = 19
+	OpUnwatch         = 20
+	OpUnwatchReply    = 21
+	OpErrorReply      = 22
+	OpAuthFailReply   = 23
+)
+
+// websocket close codes of the application
+const (
+	CloseUnauthorized = 4001
+	CloseKicked       = 4003
 )
 
 const (
@@ -34,27 +62,38 @@ const (
 
 var (
 	ErrPackLen = errors.New("proto: proto pack length error")
+	ErrOpsLen  = errors.New("proto: watch ops length error")
 )
 
 type Protocol struct {
 	*Proto
-	s *scratcher.Scratcher
 }
 
-func NewProtocol(s *scratcher.Scratcher) *Protocol {
-	return &Protocol{&Proto{}, s}
+func NewProtocol() *Protocol {
+	return &Protocol{&Proto{}}
 }
 
-func (p *Protocol) Exec() error {
-	return nil
+// splitRooms splits the comma separated room ids of the body
+func splitRooms(body []byte) []string {
+	var rids []string
+	for _, rid := range strings.Split(string(body), ",") {
+		if rid = strings.TrimSpace(rid); rid != "" {
+			rids = append(rids, rid)
+		}
+	}
+	return rids
 }
 
-func (p *Protocol) OpHeartbeat(mid int64, key, server string) error {
-	_, err := p.s.RpcClient.Heartbeat(context.Background(), &cat.HeartbeatReq{Mid: mid, Key: key, Server: server})
-	if err != nil {
-		return err
+// decodeOps decodes the big endian int32 ops of the body
+func decodeOps(body []byte) ([]int32, error) {
+	if len(body)%4 != 0 {
+		return nil, ErrOpsLen
 	}
-	return nil
+	ops := make([]int32, 0, len(body)/4)
+	for i := 0; i < len(body); i += 4 {
+		ops = append(ops, int32(binary.BigEndian.Uint32(body[i:])))
+	}
+	return ops, nil
 }
 
 func (p *Protocol) ReadWebsocket(conn *websocket.Conn) error {
@@ -67,12 +106,12 @@ func (p *Protocol) ReadWebsocket(conn *websocket.Conn) error {
 		return err
 	}
 
-	if _rawHeaderSize >= len(data) {
+	if _rawHeaderSize > len(data) {
 		return ErrPackLen
 	}
 
 	packageLen := int32(binary.BigEndian.Uint32(data[_packOffset:_verOffset]))
-	if packageLen >= maxPackLen {
+	if packageLen >= maxPackLen || packageLen < _rawHeaderSize || int(packageLen) > len(data) {
 		return ErrPackLen
 	}
 
@@ -103,7 +142,5 @@ func (p *Protocol) WriteWebsocket(conn *websocket.Conn) error {
 		return err
 	}
 
-	conn.Flush()
-
-	return nil
+	return conn.Flush()
 }
@@ -0,0 +1,1036 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: internal/protocol/scratcher/scratcher.proto
+
+package scratcher
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PushMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Op   int32    `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
+	Body []byte   `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *PushMsgReq) Reset() {
+	*x = PushMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsgReq) ProtoMessage() {}
+
+func (x *PushMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsgReq.ProtoReflect.Descriptor instead.
+func (*PushMsgReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PushMsgReq) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *PushMsgReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushMsgReq) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type PushMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// keys which are not connected to this server
+	Offline []string `protobuf:"bytes,1,rep,name=offline,proto3" json:"offline,omitempty"`
+	// keys whose channel queue is full, the message is not written to them
+	Dropped []string `protobuf:"bytes,2,rep,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *PushMsgResp) Reset() {
+	*x = PushMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsgResp) ProtoMessage() {}
+
+func (x *PushMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsgResp.ProtoReflect.Descriptor instead.
+func (*PushMsgResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PushMsgResp) GetOffline() []string {
+	if x != nil {
+		return x.Offline
+	}
+	return nil
+}
+
+func (x *PushMsgResp) GetDropped() []string {
+	if x != nil {
+		return x.Dropped
+	}
+	return nil
+}
+
+type BroadcastRoomReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Op     int32  `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
+	Body   []byte `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *BroadcastRoomReq) Reset() {
+	*x = BroadcastRoomReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRoomReq) ProtoMessage() {}
+
+func (x *BroadcastRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRoomReq.ProtoReflect.Descriptor instead.
+func (*BroadcastRoomReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BroadcastRoomReq) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *BroadcastRoomReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *BroadcastRoomReq) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type BroadcastRoomResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// channels the message was written to
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *BroadcastRoomResp) Reset() {
+	*x = BroadcastRoomResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRoomResp) ProtoMessage() {}
+
+func (x *BroadcastRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRoomResp.ProtoReflect.Descriptor instead.
+func (*BroadcastRoomResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BroadcastRoomResp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BroadcastReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op   int32  `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Body []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// messages per second, 0 means unlimited
+	Speed int32 `protobuf:"varint,3,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *BroadcastReq) Reset() {
+	*x = BroadcastReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastReq) ProtoMessage() {}
+
+func (x *BroadcastReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastReq.ProtoReflect.Descriptor instead.
+func (*BroadcastReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BroadcastReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *BroadcastReq) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *BroadcastReq) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type BroadcastResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BroadcastResp) Reset() {
+	*x = BroadcastResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastResp) ProtoMessage() {}
+
+func (x *BroadcastResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastResp.ProtoReflect.Descriptor instead.
+func (*BroadcastResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{5}
+}
+
+type RoomsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RoomsReq) Reset() {
+	*x = RoomsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomsReq) ProtoMessage() {}
+
+func (x *RoomsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomsReq.ProtoReflect.Descriptor instead.
+func (*RoomsReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{6}
+}
+
+type RoomsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// room id -> online channels
+	Rooms map[string]int32 `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RoomsResp) Reset() {
+	*x = RoomsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomsResp) ProtoMessage() {}
+
+func (x *RoomsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomsResp.ProtoReflect.Descriptor instead.
+func (*RoomsResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RoomsResp) GetRooms() map[string]int32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type KickReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys   []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Reason string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *KickReq) Reset() {
+	*x = KickReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickReq) ProtoMessage() {}
+
+func (x *KickReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickReq.ProtoReflect.Descriptor instead.
+func (*KickReq) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KickReq) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *KickReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type KickResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// keys which are not connected to this server
+	Offline []string `protobuf:"bytes,1,rep,name=offline,proto3" json:"offline,omitempty"`
+}
+
+func (x *KickResp) Reset() {
+	*x = KickResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickResp) ProtoMessage() {}
+
+func (x *KickResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_scratcher_scratcher_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickResp.ProtoReflect.Descriptor instead.
+func (*KickResp) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KickResp) GetOffline() []string {
+	if x != nil {
+		return x.Offline
+	}
+	return nil
+}
+
+var File_internal_protocol_scratcher_scratcher_proto protoreflect.FileDescriptor
+
+var file_internal_protocol_scratcher_scratcher_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2f, 0x73, 0x63,
+	0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x64,
+	0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x22, 0x44, 0x0a,
+	0x0a, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x22, 0x41, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x4e, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x29, 0x0a, 0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x48, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x42,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x0a, 0x0a, 0x08,
+	0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x22, 0x81, 0x01, 0x0a, 0x09, 0x52, 0x6f, 0x6f,
+	0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3a, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x6f, 0x6f,
+	0x6d, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x07,
+	0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x08, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x32, 0xe8, 0x02, 0x0a, 0x09, 0x73, 0x63,
+	0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x4d,
+	0x73, 0x67, 0x12, 0x1a, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x1b,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x0d, 0x42,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x2e, 0x64,
+	0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x21,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x48, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x1c,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x64,
+	0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x05, 0x52,
+	0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x18, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19,
+	0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x39, 0x0a, 0x04, 0x4b, 0x69, 0x63,
+	0x6b, 0x12, 0x17, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x72, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x64, 0x75, 0x62,
+	0x65, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x4b, 0x69, 0x63, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x78, 0x75, 0x65, 0x68, 0x61, 0x6e, 0x2f, 0x64, 0x75, 0x62, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x3b, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_protocol_scratcher_scratcher_proto_rawDescOnce sync.Once
+	file_internal_protocol_scratcher_scratcher_proto_rawDescData = file_internal_protocol_scratcher_scratcher_proto_rawDesc
+)
+
+func file_internal_protocol_scratcher_scratcher_proto_rawDescGZIP() []byte {
+	file_internal_protocol_scratcher_scratcher_proto_rawDescOnce.Do(func() {
+		file_internal_protocol_scratcher_scratcher_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_protocol_scratcher_scratcher_proto_rawDescData)
+	})
+	return file_internal_protocol_scratcher_scratcher_proto_rawDescData
+}
+
+var file_internal_protocol_scratcher_scratcher_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_internal_protocol_scratcher_scratcher_proto_goTypes = []interface{}{
+	(*PushMsgReq)(nil),        // 0: dube.scratcher.PushMsgReq
+	(*PushMsgResp)(nil),       // 1: dube.scratcher.PushMsgResp
+	(*BroadcastRoomReq)(nil),  // 2: dube.scratcher.BroadcastRoomReq
+	(*BroadcastRoomResp)(nil), // 3: dube.scratcher.BroadcastRoomResp
+	(*BroadcastReq)(nil),      // 4: dube.scratcher.BroadcastReq
+	(*BroadcastResp)(nil),     // 5: dube.scratcher.BroadcastResp
+	(*RoomsReq)(nil),          // 6: dube.scratcher.RoomsReq
+	(*RoomsResp)(nil),         // 7: dube.scratcher.RoomsResp
+	(*KickReq)(nil),           // 8: dube.scratcher.KickReq
+	(*KickResp)(nil),          // 9: dube.scratcher.KickResp
+	nil,                       // 10: dube.scratcher.RoomsResp.RoomsEntry
+}
+var file_internal_protocol_scratcher_scratcher_proto_depIdxs = []int32{
+	10, // 0: dube.scratcher.RoomsResp.rooms:type_name -> dube.scratcher.RoomsResp.RoomsEntry
+	0,  // 1: dube.scratcher.scratcher.PushMsg:input_type -> dube.scratcher.PushMsgReq
+	2,  // 2: dube.scratcher.scratcher.BroadcastRoom:input_type -> dube.scratcher.BroadcastRoomReq
+	4,  // 3: dube.scratcher.scratcher.Broadcast:input_type -> dube.scratcher.BroadcastReq
+	6,  // 4: dube.scratcher.scratcher.Rooms:input_type -> dube.scratcher.RoomsReq
+	8,  // 5: dube.scratcher.scratcher.Kick:input_type -> dube.scratcher.KickReq
+	1,  // 6: dube.scratcher.scratcher.PushMsg:output_type -> dube.scratcher.PushMsgResp
+	3,  // 7: dube.scratcher.scratcher.BroadcastRoom:output_type -> dube.scratcher.BroadcastRoomResp
+	5,  // 8: dube.scratcher.scratcher.Broadcast:output_type -> dube.scratcher.BroadcastResp
+	7,  // 9: dube.scratcher.scratcher.Rooms:output_type -> dube.scratcher.RoomsResp
+	9,  // 10: dube.scratcher.scratcher.Kick:output_type -> dube.scratcher.KickResp
+	6,  // [6:11] is the sub-list for method output_type
+	1,  // [1:6] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_internal_protocol_scratcher_scratcher_proto_init() }
+func file_internal_protocol_scratcher_scratcher_proto_init() {
+	if File_internal_protocol_scratcher_scratcher_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastRoomReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastRoomResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_protocol_scratcher_scratcher_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_protocol_scratcher_scratcher_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_protocol_scratcher_scratcher_proto_goTypes,
+		DependencyIndexes: file_internal_protocol_scratcher_scratcher_proto_depIdxs,
+		MessageInfos:      file_internal_protocol_scratcher_scratcher_proto_msgTypes,
+	}.Build()
+	File_internal_protocol_scratcher_scratcher_proto = out.File
+	file_internal_protocol_scratcher_scratcher_proto_rawDesc = nil
+	file_internal_protocol_scratcher_scratcher_proto_goTypes = nil
+	file_internal_protocol_scratcher_scratcher_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ScratcherClient is the client API for Scratcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ScratcherClient interface {
+	PushMsg(ctx context.Context, in *PushMsgReq, opts ...grpc.CallOption) (*PushMsgResp, error)
+	BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...grpc.CallOption) (*BroadcastRoomResp, error)
+	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error)
+	Rooms(ctx context.Context, in *RoomsReq, opts ...grpc.CallOption) (*RoomsResp, error)
+	Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickResp, error)
+}
+
+type scratcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScratcherClient(cc grpc.ClientConnInterface) ScratcherClient {
+	return &scratcherClient{cc}
+}
+
+func (c *scratcherClient) PushMsg(ctx context.Context, in *PushMsgReq, opts ...grpc.CallOption) (*PushMsgResp, error) {
+	out := new(PushMsgResp)
+	err := c.cc.Invoke(ctx, "/dube.scratcher.scratcher/PushMsg", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scratcherClient) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...grpc.CallOption) (*BroadcastRoomResp, error) {
+	out := new(BroadcastRoomResp)
+	err := c.cc.Invoke(ctx, "/dube.scratcher.scratcher/BroadcastRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scratcherClient) Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error) {
+	out := new(BroadcastResp)
+	err := c.cc.Invoke(ctx, "/dube.scratcher.scratcher/Broadcast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scratcherClient) Rooms(ctx context.Context, in *RoomsReq, opts ...grpc.CallOption) (*RoomsResp, error) {
+	out := new(RoomsResp)
+	err := c.cc.Invoke(ctx, "/dube.scratcher.scratcher/Rooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scratcherClient) Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickResp, error) {
+	out := new(KickResp)
+	err := c.cc.Invoke(ctx, "/dube.scratcher.scratcher/Kick", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScratcherServer is the server API for Scratcher service.
+type ScratcherServer interface {
+	PushMsg(context.Context, *PushMsgReq) (*PushMsgResp, error)
+	BroadcastRoom(context.Context, *BroadcastRoomReq) (*BroadcastRoomResp, error)
+	Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error)
+	Rooms(context.Context, *RoomsReq) (*RoomsResp, error)
+	Kick(context.Context, *KickReq) (*KickResp, error)
+}
+
+// UnimplementedScratcherServer can be embedded to have forward compatible implementations.
+type UnimplementedScratcherServer struct {
+}
+
+func (*UnimplementedScratcherServer) PushMsg(context.Context, *PushMsgReq) (*PushMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushMsg not implemented")
+}
+func (*UnimplementedScratcherServer) BroadcastRoom(context.Context, *BroadcastRoomReq) (*BroadcastRoomResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastRoom not implemented")
+}
+func (*UnimplementedScratcherServer) Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (*UnimplementedScratcherServer) Rooms(context.Context, *RoomsReq) (*RoomsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rooms not implemented")
+}
+func (*UnimplementedScratcherServer) Kick(context.Context, *KickReq) (*KickResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Kick not implemented")
+}
+
+func RegisterScratcherServer(s *grpc.Server, srv ScratcherServer) {
+	s.RegisterService(&_Scratcher_serviceDesc, srv)
+}
+
+func _Scratcher_PushMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratcherServer).PushMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.scratcher.scratcher/PushMsg",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratcherServer).PushMsg(ctx, req.(*PushMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scratcher_BroadcastRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratcherServer).BroadcastRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.scratcher.scratcher/BroadcastRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratcherServer).BroadcastRoom(ctx, req.(*BroadcastRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scratcher_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratcherServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.scratcher.scratcher/Broadcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratcherServer).Broadcast(ctx, req.(*BroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scratcher_Rooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratcherServer).Rooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.scratcher.scratcher/Rooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratcherServer).Rooms(ctx, req.(*RoomsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scratcher_Kick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratcherServer).Kick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dube.scratcher.scratcher/Kick",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratcherServer).Kick(ctx, req.(*KickReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Scratcher_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dube.scratcher.scratcher",
+	HandlerType: (*ScratcherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PushMsg",
+			Handler:    _Scratcher_PushMsg_Handler,
+		},
+		{
+			MethodName: "BroadcastRoom",
+			Handler:    _Scratcher_BroadcastRoom_Handler,
+		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _Scratcher_Broadcast_Handler,
+		},
+		{
+			MethodName: "Rooms",
+			Handler:    _Scratcher_Rooms_Handler,
+		},
+		{
+			MethodName: "Kick",
+			Handler:    _Scratcher_Kick_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/protocol/scratcher/scratcher.proto",
+}
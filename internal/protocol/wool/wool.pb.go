@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: internal/protocol/wool/wool.proto
+
+package wool
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PushMsg_Type int32
+
+const (
+	PushMsg_PUSH      PushMsg_Type = 0
+	PushMsg_ROOM      PushMsg_Type = 1
+	PushMsg_BROADCAST PushMsg_Type = 2
+)
+
+// Enum value maps for PushMsg_Type.
+var (
+	PushMsg_Type_name = map[int32]string{
+		0: "PUSH",
+		1: "ROOM",
+		2: "BROADCAST",
+	}
+	PushMsg_Type_value = map[string]int32{
+		"PUSH":      0,
+		"ROOM":      1,
+		"BROADCAST": 2,
+	}
+)
+
+func (x PushMsg_Type) Enum() *PushMsg_Type {
+	p := new(PushMsg_Type)
+	*p = x
+	return p
+}
+
+func (x PushMsg_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushMsg_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_protocol_wool_wool_proto_enumTypes[0].Descriptor()
+}
+
+func (PushMsg_Type) Type() protoreflect.EnumType {
+	return &file_internal_protocol_wool_wool_proto_enumTypes[0]
+}
+
+func (x PushMsg_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushMsg_Type.Descriptor instead.
+func (PushMsg_Type) EnumDescriptor() ([]byte, []int) {
+	return file_internal_protocol_wool_wool_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type PushMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   PushMsg_Type `protobuf:"varint,1,opt,name=type,proto3,enum=dube.wool.PushMsg_Type" json:"type,omitempty"`
+	Op     int32        `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
+	Server string       `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Keys   []string     `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	Room   string       `protobuf:"bytes,5,opt,name=room,proto3" json:"room,omitempty"`
+	Body   []byte       `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	// messages per second of broadcast
+	Speed int32 `protobuf:"varint,7,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *PushMsg) Reset() {
+	*x = PushMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_protocol_wool_wool_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsg) ProtoMessage() {}
+
+func (x *PushMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protocol_wool_wool_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsg.ProtoReflect.Descriptor instead.
+func (*PushMsg) Descriptor() ([]byte, []int) {
+	return file_internal_protocol_wool_wool_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PushMsg) GetType() PushMsg_Type {
+	if x != nil {
+		return x.Type
+	}
+	return PushMsg_PUSH
+}
+
+func (x *PushMsg) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushMsg) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *PushMsg) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *PushMsg) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *PushMsg) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *PushMsg) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+var File_internal_protocol_wool_wool_proto protoreflect.FileDescriptor
+
+var file_internal_protocol_wool_wool_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2f, 0x77, 0x6f, 0x6f, 0x6c, 0x2f, 0x77, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x75, 0x62, 0x65, 0x2e, 0x77, 0x6f, 0x6f, 0x6c, 0x22, 0xdb,
+	0x01, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x64, 0x75, 0x62, 0x65, 0x2e,
+	0x77, 0x6f, 0x6f, 0x6c, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x22, 0x29, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x55, 0x53,
+	0x48, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x42, 0x52, 0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x10, 0x02, 0x42, 0x2d, 0x5a, 0x2b,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x78, 0x75, 0x65,
+	0x68, 0x61, 0x6e, 0x2f, 0x64, 0x75, 0x62, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x2f, 0x77, 0x6f, 0x6f, 0x6c, 0x3b, 0x77, 0x6f, 0x6f, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_protocol_wool_wool_proto_rawDescOnce sync.Once
+	file_internal_protocol_wool_wool_proto_rawDescData = file_internal_protocol_wool_wool_proto_rawDesc
+)
+
+func file_internal_protocol_wool_wool_proto_rawDescGZIP() []byte {
+	file_internal_protocol_wool_wool_proto_rawDescOnce.Do(func() {
+		file_internal_protocol_wool_wool_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_protocol_wool_wool_proto_rawDescData)
+	})
+	return file_internal_protocol_wool_wool_proto_rawDescData
+}
+
+var file_internal_protocol_wool_wool_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_protocol_wool_wool_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_internal_protocol_wool_wool_proto_goTypes = []interface{}{
+	(PushMsg_Type)(0), // 0: dube.wool.PushMsg.Type
+	(*PushMsg)(nil),   // 1: dube.wool.PushMsg
+}
+var file_internal_protocol_wool_wool_proto_depIdxs = []int32{
+	0, // 0: dube.wool.PushMsg.type:type_name -> dube.wool.PushMsg.Type
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_internal_protocol_wool_wool_proto_init() }
+func file_internal_protocol_wool_wool_proto_init() {
+	if File_internal_protocol_wool_wool_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_protocol_wool_wool_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_protocol_wool_wool_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_protocol_wool_wool_proto_goTypes,
+		DependencyIndexes: file_internal_protocol_wool_wool_proto_depIdxs,
+		EnumInfos:         file_internal_protocol_wool_wool_proto_enumTypes,
+		MessageInfos:      file_internal_protocol_wool_wool_proto_msgTypes,
+	}.Build()
+	File_internal_protocol_wool_wool_proto = out.File
+	file_internal_protocol_wool_wool_proto_rawDesc = nil
+	file_internal_protocol_wool_wool_proto_goTypes = nil
+	file_internal_protocol_wool_wool_proto_depIdxs = nil
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"github.com/BurntSushi/toml"
 	"os"
+	"time"
 )
 
 type Options struct {
@@ -13,23 +14,36 @@ type Options struct {
 	RPCServer *RPCServer
 	Env       *Env
 	Bucket    *Bucket
+	Timer     *Timer
+	Online    *Online
+	Registry  *Registry
+	Node      *Node
 }
 
 type WebSocket struct {
-	Bind            []string
-	KeepAlive       bool
-	ReadBufferSize  int
-	WriteBufferSize int
+	Bind             []string
+	KeepAlive        bool
+	ReadBufferSize   int
+	WriteBufferSize  int
+	HandshakeTimeout otime.Duration
 }
 
 type RPCClient struct {
-	Dial    otime.Duration
-	Timeout otime.Duration
+	Dial     otime.Duration
+	Timeout  otime.Duration
+	Addrs    []string //static cat addresses
+	Discover bool     //discover the cat instances in the registry
 }
 
 type RPCServer struct {
-	Dial    string
-	Timeout string
+	Network           string
+	Addr              string
+	Timeout           otime.Duration
+	IdleTimeout       otime.Duration
+	MaxLifeTime       otime.Duration
+	ForceCloseWait    otime.Duration
+	KeepAliveInterval otime.Duration
+	KeepAliveTimeout  otime.Duration
 }
 
 type Bucket struct {
@@ -38,6 +52,31 @@ type Bucket struct {
 	Room    int32
 }
 
+type Timer struct {
+	Size  int
+	Slots int
+	Tick  otime.Duration
+}
+
+type Online struct {
+	Renew otime.Duration
+}
+
+type Registry struct {
+	Network string
+	Addr    string //"" not registered
+	Auth    string
+	Timeout otime.Duration
+	TTL     otime.Duration
+}
+
+// Node is how the scratcher is advertised in the registry
+type Node struct {
+	RPCAddr string
+	Addrs   []string //websocket addresses the clients connect to
+	Weight  float64
+}
+
 type Env struct {
 	Region string
 	Zone   string
@@ -67,6 +106,39 @@ func Default() (*Options, error) {
 			Zone:   zone,
 			Host:   host,
 		},
+		WebSocket: &WebSocket{
+			Bind:             []string{":9999"},
+			ReadBufferSize:   4096,
+			WriteBufferSize:  4096,
+			HandshakeTimeout: otime.Duration(time.Second * 5),
+		},
+		RPCClient: &RPCClient{
+			Dial:    otime.Duration(time.Second),
+			Timeout: otime.Duration(time.Second),
+		},
+		RPCServer: &RPCServer{
+			Network:           "tcp",
+			Addr:              ":3109",
+			Timeout:           otime.Duration(time.Second),
+			IdleTimeout:       otime.Duration(time.Second * 60),
+			MaxLifeTime:       otime.Duration(time.Hour * 2),
+			ForceCloseWait:    otime.Duration(time.Second * 20),
+			KeepAliveInterval: otime.Duration(time.Second * 60),
+			KeepAliveTimeout:  otime.Duration(time.Second * 20),
+		},
+		Bucket: &Bucket{
+			Size:    32,
+			Channel: 1024,
+			Room:    1024,
+		},
+		Timer: &Timer{
+			Size:  32,
+			Slots: 512,
+			Tick:  otime.Duration(time.Second),
+		},
+		Online: &Online{
+			Renew: otime.Duration(time.Second * 10),
+		},
 	}
 	_, err := toml.DecodeFile(confPath, &options)
 	if err != nil {
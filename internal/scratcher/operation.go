@@ -0,0 +1,104 @@
+package scratcher
+
+import (
+	"context"
+	pb "dube/internal/protocol/cat"
+	log "github.com/golang/glog"
+	"time"
+)
+
+// session executes the client operations of a channel
+type session struct {
+	srv    *Scratcher
+	b      *Bucket
+	ch     *Channel
+	hb     time.Duration
+	lastHb time.Time
+}
+
+func newSession(srv *Scratcher, b *Bucket, ch *Channel) *session {
+	return &session{srv: srv, b: b, ch: ch, hb: srv.RandServerHeartbeat(), lastHb: time.Now()}
+}
+
+// Heartbeat refreshes the session of the channel in cat, at most once every server heartbeat
+func (s *session) Heartbeat() error {
+	now := time.Now()
+	if now.Sub(s.lastHb) <= s.hb {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.srv.Conf.RPCClient.Timeout))
+	defer cancel()
+
+	if _, err := s.srv.RpcClient.Heartbeat(ctx, &pb.HeartbeatReq{
+		Mid:    s.ch.mid,
+		Key:    s.ch.key,
+		Server: s.srv.ServerID,
+	}); err != nil {
+		log.Errorf("heartbeat key(%s) to cat error - (%v)", s.ch.key, err)
+		return nil
+	}
+	s.lastHb = now
+	return nil
+}
+
+// Online returns the online count of the channel room on every scratcher
+func (s *session) Online() int32 {
+	return s.srv.AllRoomOnline(s.ch.roomID)
+}
+
+// ChangeRoom moves the channel into another room and reports the online of both rooms to cat
+func (s *session) ChangeRoom(rid string) error {
+	old := s.ch.roomID
+	if err := s.b.changeRoom(s.ch, rid); err != nil {
+		return err
+	}
+	s.reportOnline(old, rid)
+	return nil
+}
+
+// Sub joins the channel into the rooms and reports their online to cat
+func (s *session) Sub(rids []string) error {
+	if err := s.b.sub(s.ch, rids); err != nil {
+		return err
+	}
+	s.reportOnline(rids...)
+	return nil
+}
+
+// Unsub removes the channel from the rooms and reports their online to cat
+func (s *session) Unsub(rids []string) error {
+	s.b.unsub(s.ch, rids)
+	s.reportOnline(rids...)
+	return nil
+}
+
+func (s *session) Watch(ops []int32) {
+	s.ch.Watch(ops...)
+}
+
+func (s *session) Unwatch(ops []int32) {
+	s.ch.Unwatch(ops...)
+}
+
+func (s *session) reportOnline(rids ...string) {
+	online := make(map[string]int32, len(rids))
+	for _, rid := range rids {
+		if rid != "" {
+			online[rid] = s.srv.RoomOnline(rid)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.srv.Conf.RPCClient.Timeout))
+	defer cancel()
+
+	if _, err := s.srv.RpcClient.ChangeRoom(ctx, &pb.ChangeRoomReq{
+		Mid:    s.ch.mid,
+		Key:    s.ch.key,
+		Server: s.srv.ServerID,
+		RoomID: s.ch.roomID,
+		Online: online,
+	}); err != nil {
+		log.Errorf("report rooms(%v) online key(%s) to cat error - (%v)", rids, s.ch.key, err)
+	}
+}
@@ -0,0 +1,81 @@
+package scratcher
+
+import (
+	"context"
+	"dube/internal/scratcher/conf"
+	"dube/pkg/otime"
+	"dube/pkg/registry"
+	log "github.com/golang/glog"
+	"time"
+)
+
+const (
+	DefaultRegistryTTL = 30 * time.Second
+)
+
+// newRegistry returns the registry of the app, nil if it's not configured
+func newRegistry(c *conf.Registry, app string) registry.Registry {
+	if c == nil || c.Addr == "" {
+		return nil
+	}
+	if c.TTL <= 0 {
+		c.TTL = otime.Duration(DefaultRegistryTTL)
+	}
+	pool := registry.NewRedisPool(c.Network, c.Addr, c.Auth, time.Duration(c.Timeout))
+	return registry.NewRedis(pool, app, time.Duration(c.TTL))
+}
+
+// Node returns the scratcher node with its live connections
+func (s *Scratcher) Node() *registry.Node {
+	n := &registry.Node{
+		Server: s.ServerID,
+		Region: s.Conf.Env.Region,
+		Zone:   s.Conf.Env.Zone,
+	}
+	if c := s.Conf.Node; c != nil {
+		n.RPCAddr = c.RPCAddr
+		n.Addrs = c.Addrs
+		n.Weight = c.Weight
+	}
+	for _, b := range s.Buckets {
+		n.Online += b.size()
+	}
+	return n
+}
+
+// registerproc renews the node three times per ttl until the scratcher is closed
+func (s *Scratcher) registerproc() {
+	defer s.procs.Done()
+
+	ticker := time.NewTicker(time.Duration(s.Conf.Registry.TTL) / 3)
+	defer ticker.Stop()
+
+	for {
+		s.register()
+		select {
+		case <-ticker.C:
+		case <-s.closed:
+			s.deregister()
+			return
+		}
+	}
+}
+
+func (s *Scratcher) register() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.Conf.Registry.Timeout))
+	defer cancel()
+
+	if err := s.Registry.Register(ctx, s.Node()); err != nil {
+		log.Errorf("register node(%s) error - (%v)", s.ServerID, err)
+	}
+}
+
+func (s *Scratcher) deregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.Conf.Registry.Timeout))
+	defer cancel()
+
+	if err := s.Registry.Deregister(ctx, s.ServerID); err != nil {
+		log.Errorf("deregister node(%s) error - (%v)", s.ServerID, err)
+	}
+	s.Registry.Close()
+}
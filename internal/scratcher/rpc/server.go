@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	pb "dube/internal/protocol/scratcher"
+	"dube/internal/scratcher"
+	"dube/internal/scratcher/conf"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"net"
+	"time"
+)
+
+var (
+	ErrPushMsgArg       = errors.New("rpc: pushmsg arg error")
+	ErrBroadcastRoomArg = errors.New("rpc: broadcast room arg error")
+	ErrBroadcastArg     = errors.New("rpc: broadcast arg error")
+	ErrKickArg          = errors.New("rpc: kick arg error")
+)
+
+type Server struct {
+	srv *scratcher.Scratcher
+}
+
+func New(c *conf.RPCServer, s *scratcher.Scratcher) *grpc.Server {
+	opt := grpc.KeepaliveParams(keepalive.ServerParameters{
+		MaxConnectionIdle:     time.Duration(c.IdleTimeout),
+		MaxConnectionAge:      time.Duration(c.MaxLifeTime),
+		MaxConnectionAgeGrace: time.Duration(c.ForceCloseWait),
+		Timeout:               time.Duration(c.KeepAliveTimeout),
+		Time:                  time.Duration(c.KeepAliveInterval),
+	})
+
+	srv := grpc.NewServer(opt)
+	pb.RegisterScratcherServer(srv, &Server{s})
+
+	l, err := net.Listen(c.Network, c.Addr)
+	if err != nil {
+		panic(err)
+	}
+
+	go func() {
+		if err = srv.Serve(l); err != nil {
+			panic(err)
+		}
+	}()
+
+	return srv
+}
+
+func (s *Server) PushMsg(ctx context.Context, req *pb.PushMsgReq) (*pb.PushMsgResp, error) {
+	if len(req.GetKeys()) == 0 {
+		return nil, ErrPushMsgArg
+	}
+	resp := new(pb.PushMsgResp)
+	resp.Offline, resp.Dropped = s.srv.PushKeys(req.GetKeys(), req.GetOp(), req.GetBody())
+	return resp, nil
+}
+
+func (s *Server) BroadcastRoom(ctx context.Context, req *pb.BroadcastRoomReq) (*pb.BroadcastRoomResp, error) {
+	if req.GetRoomID() == "" {
+		return nil, ErrBroadcastRoomArg
+	}
+	resp := new(pb.BroadcastRoomResp)
+	resp.Count = s.srv.BroadcastRoom(req.GetRoomID(), req.GetOp(), req.GetBody())
+	return resp, nil
+}
+
+func (s *Server) Broadcast(ctx context.Context, req *pb.BroadcastReq) (*pb.BroadcastResp, error) {
+	if req.GetSpeed() < 0 {
+		return nil, ErrBroadcastArg
+	}
+	// throttled broadcast may take a long time, don't block the caller
+	go s.srv.Broadcast(req.GetOp(), req.GetBody(), req.GetSpeed())
+	return &pb.BroadcastResp{}, nil
+}
+
+func (s *Server) Rooms(ctx context.Context, req *pb.RoomsReq) (*pb.RoomsResp, error) {
+	return &pb.RoomsResp{Rooms: s.srv.Rooms()}, nil
+}
+
+func (s *Server) Kick(ctx context.Context, req *pb.KickReq) (*pb.KickResp, error) {
+	if len(req.GetKeys()) == 0 {
+		return nil, ErrKickArg
+	}
+	return &pb.KickResp{Offline: s.srv.Kick(req.GetKeys(), req.GetReason())}, nil
+}
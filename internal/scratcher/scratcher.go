@@ -5,16 +5,29 @@ import (
 	"dube/internal/protocol"
 	pb "dube/internal/protocol/cat"
 	"dube/internal/scratcher/conf"
+	"dube/pkg/registry"
+	"dube/pkg/timer"
 	"dube/pkg/websocket"
+	"errors"
 	"fmt"
+	log "github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"hash/fnv"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	catDiscoverInterval = 5 * time.Second
+	// kickWriteTimeout bounds writing the disconnect of a kicked client
+	kickWriteTimeout = time.Second
+)
+
 const (
 	grpcInitialWindowSize     = 1 << 24
 	grpcInitialConnWindowSize = 1 << 24
@@ -25,30 +38,191 @@ const (
 	grpcKeepAliveTimeout      = 3 * time.Second
 )
 
+// KickReplaced is the kick reason of a channel whose key connects again
+const KickReplaced = "replaced"
+
+var (
+	ErrChannelFull = errors.New("scratcher: channel queue is full")
+	ErrRoomDropped = errors.New("scratcher: room dropped")
+	ErrNoRegistry  = errors.New("scratcher: cat discovery needs the registry")
+)
+
 const (
 	MaxServerHeartbeat = 30 * time.Minute
 	MinServerHeartbeat = 10 * time.Minute
+	// DefaultHeartbeat is the client heartbeat timeout if cat doesn't give one
+	DefaultHeartbeat = 5 * time.Minute
+	// DefaultHandshakeTimeout bounds the websocket handshake and auth
+	DefaultHandshakeTimeout = 5 * time.Second
+	// DefaultCatAddr is dialed if neither cat addresses nor discovery are configured
+	DefaultCatAddr = "127.0.0.1:3119"
+	// DefaultOnlineRenew is how often the room online is reported to cat
+	DefaultOnlineRenew = 10 * time.Second
 )
 
+// AuthError is the rejection of the client token by cat
+type AuthError struct {
+	Reason string
+}
+
+func (e *AuthError) Error() string {
+	return "scratcher: auth rejected - " + e.Reason
+}
+
 type Channel struct {
-	mid    int64
-	key    string
-	roomID string
-	conn   *websocket.Conn
-	q      chan string
-	next   *Channel
+	mid      int64
+	key      string
+	roomID   string
+	rooms    map[string]*member
+	conn     *websocket.Conn
+	q        chan *protocol.Proto
+	opsLock  sync.RWMutex
+	watchOps map[int32]struct{}
 }
 
 func NewChannel() *Channel {
-	return &Channel{}
+	return &Channel{
+		rooms:    make(map[string]*member),
+		watchOps: make(map[int32]struct{}),
+	}
+}
+
+// Watch subscribes the ops the client wants to receive
+func (c *Channel) Watch(ops ...int32) {
+	c.opsLock.Lock()
+	for _, op := range ops {
+		c.watchOps[op] = struct{}{}
+	}
+	c.opsLock.Unlock()
+}
+
+func (c *Channel) Unwatch(ops ...int32) {
+	c.opsLock.Lock()
+	for _, op := range ops {
+		delete(c.watchOps, op)
+	}
+	c.opsLock.Unlock()
+}
+
+// NeedPush reports whether the client wants pushes of op, all ops are wanted if none is watched
+func (c *Channel) NeedPush(op int32) bool {
+	c.opsLock.RLock()
+	defer c.opsLock.RUnlock()
+
+	if len(c.watchOps) == 0 {
+		return true
+	}
+	_, ok := c.watchOps[op]
+	return ok
 }
 
+// write the message to the websocket, safe for concurrent use
+func (c *Channel) write(p *protocol.Proto) error {
+	c.conn.Lock()
+	defer c.conn.Unlock()
+	return (&protocol.Protocol{Proto: p}).WriteWebsocket(c.conn)
+}
+
+// Kick tells the client why it is disconnected and closes the conn without waiting,
+// the read loop then fails and removes the channel. A stalled client is closed once
+// the write deadline passes
+func (c *Channel) Kick(reason string) {
+	c.conn.SetWriteDeadline(time.Now().Add(kickWriteTimeout))
+	go func() {
+		p := &protocol.Proto{Ver: protocol.Version, Op: protocol.OpDisconnectReply, Body: []byte(reason)}
+		if err := c.write(p); err == nil {
+			c.conn.WriteClose(protocol.CloseKicked, reason)
+		}
+		c.conn.Close()
+	}()
+}
+
+// Push message into the channel queue without blocking
+func (c *Channel) Push(p *protocol.Proto) error {
+	select {
+	case c.q <- p:
+	default:
+		return ErrChannelFull
+	}
+	return nil
+}
+
+// member links a channel into one of its rooms
+type member struct {
+	room *Room
+	ch   *Channel
+	prev *member
+	next *member
+}
+
+// Room is a doubly linked list of the channels in it, it is dropped once empty
 type Room struct {
-	Next *Channel
+	ID     string
+	lock   sync.RWMutex
+	next   *member
+	drop   bool
+	online int32
+}
+
+func NewRoom(id string) *Room {
+	return &Room{ID: id}
+}
+
+func (r *Room) Put(ch *Channel) (*member, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.drop {
+		return nil, ErrRoomDropped
+	}
+	m := &member{room: r, ch: ch, next: r.next}
+	if r.next != nil {
+		r.next.prev = m
+	}
+	r.next = m
+	r.online++
+	return m, nil
+}
+
+// Del removes the member, returns true if the room is dropped
+func (r *Room) Del(m *member) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if m.next != nil {
+		m.next.prev = m.prev
+	}
+	if m.prev != nil {
+		m.prev.next = m.next
+	} else {
+		r.next = m.next
+	}
+	m.next = nil
+	m.prev = nil
+	r.online--
+	r.drop = r.online == 0
+	return r.drop
+}
+
+// Push message to every channel in the room, returns the count written
+func (r *Room) Push(p *protocol.Proto) (n int32) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for m := r.next; m != nil; m = m.next {
+		if err := m.ch.Push(p); err != nil {
+			log.Errorf("push room(%s) key(%s) error - (%v)", r.ID, m.ch.key, err)
+			continue
+		}
+		n++
+	}
+	return
 }
 
-func NewRoom() *Room {
-	return &Room{}
+func (r *Room) Online() int32 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.online
 }
 
 type Bucket struct {
@@ -64,22 +238,20 @@ func NewBucket(c *conf.Bucket) *Bucket {
 	}
 }
 
-// put channel
+// put channel, the channel having the same key is kicked
 func (b *Bucket) put(ch *Channel) error {
 	b.Lock()
+	defer b.Unlock()
+
+	if dch, ok := b.channelMap[ch.key]; ok {
+		b.leaveAll(dch)
+		dch.Kick(KickReplaced)
+	}
 	b.channelMap[ch.key] = ch
 
 	if ch.roomID != "" {
-
-		if _, ok := b.roomsMap[ch.roomID]; !ok {
-			b.roomsMap[ch.roomID] = NewRoom()
-			b.roomsMap[ch.roomID].Next = ch
-		} else {
-			ch.next = b.roomsMap[ch.roomID].Next
-			b.roomsMap[ch.roomID].Next = ch
-		}
+		return b.join(ch, ch.roomID)
 	}
-	b.Unlock()
 	return nil
 }
 
@@ -93,15 +265,137 @@ func (b *Bucket) get(key string) (*Channel, error) {
 	return nil, fmt.Errorf("get key(%s) in bucket error", key)
 }
 
-func (b *Bucket) del(ch *Channel) {
+// changeRoom moves the channel from its room into room rid, rid "" only leaves the room
+func (b *Bucket) changeRoom(ch *Channel, rid string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if ch.roomID != "" {
+		b.leave(ch, ch.roomID)
+	}
+	ch.roomID = rid
+	if rid == "" {
+		return nil
+	}
+	return b.join(ch, rid)
+}
+
+// sub joins the channel into the rooms besides its current room
+func (b *Bucket) sub(ch *Channel, rids []string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	for _, rid := range rids {
+		if err := b.join(ch, rid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unsub removes the channel from the rooms
+func (b *Bucket) unsub(ch *Channel, rids []string) {
 	b.Lock()
 	defer b.Unlock()
 
-	delete(b.channelMap, ch.key)
+	for _, rid := range rids {
+		if rid == ch.roomID {
+			ch.roomID = ""
+		}
+		b.leave(ch, rid)
+	}
+}
+
+// del removes the channel, returns false if the key was taken over by another channel
+func (b *Bucket) del(ch *Channel) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	b.leaveAll(ch)
+	if dch, ok := b.channelMap[ch.key]; ok && dch == ch {
+		delete(b.channelMap, ch.key)
+		return true
+	}
+	return false
+}
+
+// join puts the channel into room rid once, bucket lock must be held
+func (b *Bucket) join(ch *Channel, rid string) error {
+	if _, ok := ch.rooms[rid]; ok || rid == "" {
+		return nil
+	}
+
+	room, ok := b.roomsMap[rid]
+	if !ok {
+		room = NewRoom(rid)
+		b.roomsMap[rid] = room
+	}
+	m, err := room.Put(ch)
+	if err != nil {
+		return err
+	}
+	ch.rooms[rid] = m
+	return nil
+}
+
+// leave removes the channel from room rid and reclaims the room if empty, bucket lock must be held
+func (b *Bucket) leave(ch *Channel, rid string) {
+	m, ok := ch.rooms[rid]
+	if !ok {
+		return
+	}
+	if m.room.Del(m) {
+		if room, ok := b.roomsMap[rid]; ok && room == m.room {
+			delete(b.roomsMap, rid)
+		}
+	}
+	delete(ch.rooms, rid)
+}
+
+// leaveAll removes the channel from all its rooms, bucket lock must be held
+func (b *Bucket) leaveAll(ch *Channel) {
+	for rid := range ch.rooms {
+		b.leave(ch, rid)
+	}
+}
+
+// broadcastRoom push message to every channel in the room
+func (b *Bucket) broadcastRoom(rid string, p *protocol.Proto) int32 {
+	if room := b.room(rid); room != nil {
+		return room.Push(p)
+	}
+	return 0
+}
+
+// channels returns a snapshot of all channels in the bucket
+func (b *Bucket) channels() []*Channel {
+	b.RLock()
+	defer b.RUnlock()
+
+	chs := make([]*Channel, 0, len(b.channelMap))
+	for _, ch := range b.channelMap {
+		chs = append(chs, ch)
+	}
+	return chs
+}
+
+// rooms returns the online channels of every room in the bucket
+func (b *Bucket) rooms() map[string]int32 {
+	b.RLock()
+	defer b.RUnlock()
 
-	if _, ok := b.roomsMap[ch.roomID]; ok {
-		delete(b.roomsMap, ch.roomID)
+	res := make(map[string]int32, len(b.roomsMap))
+	for rid, room := range b.roomsMap {
+		res[rid] = room.Online()
 	}
+	return res
+}
+
+// size returns the count of channels
+func (b *Bucket) size() int32 {
+	b.RLock()
+	defer b.RUnlock()
+	return int32(len(b.channelMap))
 }
 
 func (b *Bucket) room(rid string) *Room {
@@ -111,20 +405,38 @@ func (b *Bucket) room(rid string) *Room {
 }
 
 type Scratcher struct {
-	Conf          *conf.Options
-	RpcClient     pb.CatClient
-	ServerID      string
-	Bucket        *Bucket
-	LastHeartbeat time.Time
+	Conf      *conf.Options
+	RpcClient pb.CatClient
+	ServerID  string
+	Registry  registry.Registry
+	rpcConn   *grpc.ClientConn
+	Buckets   []*Bucket
+	bucketIdx uint32
+	wheels    []*timer.Wheel
+	wheelIdx  uint32
+	// online of the rooms on every scratcher, renewed from cat
+	onlineLock   sync.RWMutex
+	allRoomCount map[string]int32
+	closed       chan struct{}
+	procs        sync.WaitGroup
 }
 
-func NewRPCClient(c *conf.RPCClient) pb.CatClient {
+// NewRPCClient dials the cat instances of the static addresses and the registry if r is not nil,
+// calls are balanced round robin across them
+func NewRPCClient(c *conf.RPCClient, r registry.Registry) (*grpc.ClientConn, error) {
+	addrs := c.Addrs
+	if len(addrs) == 0 && r == nil {
+		addrs = []string{DefaultCatAddr}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Dial))
 	defer cancel()
 
-	cc, err := grpc.DialContext(ctx, "127.0.0.1:3119",
+	return grpc.DialContext(ctx, registry.Target(registry.AppCat),
 		[]grpc.DialOption{
 			grpc.WithInsecure(),
+			grpc.WithBlock(),
+			grpc.WithResolvers(registry.NewBuilder(addrs, r, catDiscoverInterval)),
 			grpc.WithInitialWindowSize(grpcInitialWindowSize),
 			grpc.WithInitialConnWindowSize(grpcInitialConnWindowSize),
 			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxCallMsgSize)),
@@ -137,29 +449,90 @@ func NewRPCClient(c *conf.RPCClient) pb.CatClient {
 			}),
 			grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, roundrobin.Name)),
 		}...)
+}
+
+func New(c *conf.Options) (*Scratcher, error) {
+	s := &Scratcher{
+		Conf:     c,
+		ServerID: c.Env.Host,
+		closed:   make(chan struct{}),
+	}
+
+	var cats registry.Registry
+	if c.RPCClient.Discover {
+		if cats = newRegistry(c.Registry, registry.AppCat); cats == nil {
+			return nil, ErrNoRegistry
+		}
+	}
+	cc, err := NewRPCClient(c.RPCClient, cats)
 	if err != nil {
-		panic(err)
+		if cats != nil {
+			cats.Close()
+		}
+		return nil, err
+	}
+	s.rpcConn = cc
+	s.RpcClient = pb.NewCatClient(cc)
+
+	if c.Bucket.Size <= 0 {
+		c.Bucket.Size = 1
+	}
+	s.Buckets = make([]*Bucket, c.Bucket.Size)
+	s.bucketIdx = uint32(c.Bucket.Size)
+	for i := range s.Buckets {
+		s.Buckets[i] = NewBucket(c.Bucket)
 	}
 
-	return pb.NewCatClient(cc)
+	if c.Timer.Size <= 0 {
+		c.Timer.Size = 1
+	}
+	s.wheels = make([]*timer.Wheel, c.Timer.Size)
+	for i := range s.wheels {
+		s.wheels[i] = timer.NewWheel(time.Duration(c.Timer.Tick), c.Timer.Slots)
+	}
+
+	s.procs.Add(1)
+	go s.onlineproc()
+	if s.Registry = newRegistry(c.Registry, registry.AppScratcher); s.Registry != nil {
+		s.procs.Add(1)
+		go s.registerproc()
+	}
+	return s, nil
+}
+
+// Wheel returns the timing wheels in turn to spread the connections
+func (s *Scratcher) Wheel() *timer.Wheel {
+	return s.wheels[atomic.AddUint32(&s.wheelIdx, 1)%uint32(len(s.wheels))]
 }
 
-func New(c *conf.Options) *Scratcher {
-	return &Scratcher{
-		c,
-		NewRPCClient(c.RPCClient),
-		c.Env.Host,
-		NewBucket(c.Bucket),
-		time.Now(),
+// Close stops the background routines, the node is deregistered when it returns
+func (s *Scratcher) Close() {
+	close(s.closed)
+	s.procs.Wait()
+	for _, w := range s.wheels {
+		w.Stop()
 	}
+	s.rpcConn.Close()
 }
 
+// Bucket returns the bucket of the channel key
+func (s *Scratcher) Bucket(key string) *Bucket {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.Buckets[h.Sum32()%s.bucketIdx]
+}
+
+// Auth identifies the client by the token of its first message, the key is returned once cat
+// identified the client, even if replying to the client fails
 func (s *Scratcher) Auth(ctx context.Context, p *protocol.Protocol, conn *websocket.Conn) (mid int64, key, roomID string, hb int64, err error) {
 
 	if err = p.ReadWebsocket(conn); err != nil {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.Conf.RPCClient.Timeout))
+	defer cancel()
+
 	resp, err := s.RpcClient.Identify(ctx, &pb.IdentifyReq{
 		Server: s.ServerID,
 		Token:  p.Body,
@@ -169,29 +542,210 @@ func (s *Scratcher) Auth(ctx context.Context, p *protocol.Protocol, conn *websoc
 		return
 	}
 
-	p.Op = protocol.OpAuthReply
-	if err = p.WriteWebsocket(conn); err != nil {
+	if resp.Reason != "" {
+		err = &AuthError{Reason: resp.Reason}
 		return
 	}
 
-	return resp.Mid, resp.Key, resp.RoomID, resp.Heartbeat, nil
+	mid, key, roomID, hb = resp.Mid, resp.Key, resp.RoomID, resp.Heartbeat
+	p.Op = protocol.OpAuthReply
+	err = p.WriteWebsocket(conn)
+	return
+}
+
+// authFailed tells the client why it's not authenticated before the conn is closed,
+// a rejected token is answered with OpAuthFailReply and CloseUnauthorized
+func (s *Scratcher) authFailed(p *protocol.Protocol, conn *websocket.Conn, err error) {
+	var reject *AuthError
+	switch {
+	case errors.As(err, &reject):
+		p.Op = protocol.OpAuthFailReply
+		p.Body = []byte(reject.Reason)
+		if err = p.WriteWebsocket(conn); err == nil {
+			conn.WriteClose(protocol.CloseUnauthorized, reject.Reason)
+		}
+	case err == protocol.ErrPackLen:
+		conn.WriteClose(websocket.CloseProtocolError, err.Error())
+	default:
+		if _, ok := status.FromError(err); ok {
+			conn.WriteClose(websocket.CloseInternalServerErr, "auth unavailable")
+		}
+	}
+}
+
+// PushKeys push message to the channels of keys, returns keys not connected here
+// and keys whose channel queue is full
+func (s *Scratcher) PushKeys(keys []string, op int32, body []byte) (offline, dropped []string) {
+	p := &protocol.Proto{Ver: protocol.Version, Op: op, Body: body}
+	for _, key := range keys {
+		ch, err := s.Bucket(key).get(key)
+		if err != nil {
+			offline = append(offline, key)
+			continue
+		}
+		if err = ch.Push(p); err != nil {
+			log.Errorf("push key(%s) op(%d) error - (%v)", key, op, err)
+			dropped = append(dropped, key)
+		}
+	}
+	return
+}
+
+// Kick disconnects the keys with the reason, returns keys not connected to this server
+func (s *Scratcher) Kick(keys []string, reason string) (offline []string) {
+	for _, key := range keys {
+		ch, err := s.Bucket(key).get(key)
+		if err != nil {
+			offline = append(offline, key)
+			continue
+		}
+		ch.Kick(reason)
+	}
+	return
+}
+
+// BroadcastRoom push message to the channels of the room, returns the count written
+func (s *Scratcher) BroadcastRoom(roomID string, op int32, body []byte) (n int32) {
+	p := &protocol.Proto{Ver: protocol.Version, Op: op, Body: body}
+	for _, b := range s.Buckets {
+		n += b.broadcastRoom(roomID, p)
+	}
+	return
+}
+
+// Broadcast push message to every channel, at most speed messages per second if speed > 0
+func (s *Scratcher) Broadcast(op int32, body []byte, speed int32) {
+	var (
+		n     int32
+		start = time.Now()
+		p     = &protocol.Proto{Ver: protocol.Version, Op: op, Body: body}
+	)
+	for _, b := range s.Buckets {
+		for _, ch := range b.channels() {
+			if err := ch.Push(p); err != nil {
+				log.Errorf("broadcast key(%s) op(%d) error - (%v)", ch.key, op, err)
+			}
+
+			if n++; speed > 0 && n%speed == 0 {
+				if d := time.Second - time.Since(start); d > 0 {
+					time.Sleep(d)
+				}
+				start = time.Now()
+			}
+		}
+	}
+}
+
+// Rooms returns the online channels of every room on this server
+func (s *Scratcher) Rooms() map[string]int32 {
+	res := make(map[string]int32)
+	for _, b := range s.Buckets {
+		for rid, n := range b.rooms() {
+			res[rid] += n
+		}
+	}
+	return res
 }
 
-func (s *Scratcher) Handle(p *protocol.Protocol) error {
-	return p.Exec()
+// RoomOnline returns the online channels of the room on this server
+func (s *Scratcher) RoomOnline(rid string) (n int32) {
+	for _, b := range s.Buckets {
+		if room := b.room(rid); room != nil {
+			n += room.Online()
+		}
+	}
+	return
+}
+
+// AllRoomOnline returns the online of the room on every scratcher, it falls back to the
+// online of this scratcher until cat counts the room
+func (s *Scratcher) AllRoomOnline(rid string) int32 {
+	n := s.RoomOnline(rid)
+	s.onlineLock.RLock()
+	all := s.allRoomCount[rid]
+	s.onlineLock.RUnlock()
+	if all > n {
+		return all
+	}
+	return n
+}
+
+// onlineproc reports the online of every room to cat periodically
+func (s *Scratcher) onlineproc() {
+	defer s.procs.Done()
+
+	renew := time.Duration(s.Conf.Online.Renew)
+	if renew <= 0 {
+		renew = DefaultOnlineRenew
+	}
+	ticker := time.NewTicker(renew)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.closed:
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.Conf.RPCClient.Timeout))
+		resp, err := s.RpcClient.RenewOnline(ctx, &pb.RenewOnlineReq{
+			Server:    s.ServerID,
+			RoomCount: s.Rooms(),
+		})
+		cancel()
+		if err != nil {
+			log.Errorf("renew online to cat error - (%v)", err)
+			continue
+		}
+		s.onlineLock.Lock()
+		s.allRoomCount = resp.GetAllRoomCount()
+		s.onlineLock.Unlock()
+	}
+}
+
+// Disconnect tells cat the key is offline
+func (s *Scratcher) Disconnect(ctx context.Context, mid int64, key string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.Conf.RPCClient.Timeout))
+	defer cancel()
+
+	if _, err := s.RpcClient.Disconnect(ctx, &pb.DisconnectReq{
+		Mid:    mid,
+		Key:    key,
+		Server: s.ServerID,
+	}); err != nil {
+		log.Errorf("disconnect mid(%d) key(%s) to cat error - (%v)", mid, key, err)
+	}
+}
+
+// Handle executes the client operation and writes the reply
+func (s *Scratcher) Handle(p *protocol.Protocol, sess *session) error {
+	reply, err := p.Exec(sess)
+	if err != nil || !reply {
+		return err
+	}
+	return sess.ch.write(p.Proto)
 }
 
 func (s *Scratcher) RandServerHeartbeat() time.Duration {
 	return MinServerHeartbeat + time.Duration(rand.Int63n(int64(MaxServerHeartbeat-MinServerHeartbeat)))
 }
 
-func (s *Scratcher) Dispatch(ctx context.Context, channel *Channel) {
+// Dispatch writes the queued pushes the client watches to the websocket, the connection is closed on write failure
+func (s *Scratcher) Dispatch(ctx context.Context, ch *Channel) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case c := <-channel.q:
-			fmt.Println(c)
+		case p := <-ch.q:
+			if !ch.NeedPush(p.Op) {
+				continue
+			}
+			if err := ch.write(p); err != nil {
+				log.Errorf("dispatch key(%s) op(%d) error - (%v)", ch.key, p.Op, err)
+				ch.conn.Close()
+				return
+			}
 		}
 	}
 }
@@ -0,0 +1,190 @@
+package scratcher
+
+import (
+	"bufio"
+	"bytes"
+	"dube/internal/protocol"
+	"dube/internal/scratcher/conf"
+	"dube/pkg/websocket"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestChannel(key, rid string) *Channel {
+	ch := NewChannel()
+	ch.key = key
+	ch.roomID = rid
+	ch.q = make(chan *protocol.Proto, 8)
+	return ch
+}
+
+// newTestConn returns the websocket of the channel and the client end of it
+func newTestConn(t *testing.T) (*websocket.Conn, net.Conn) {
+	t.Helper()
+	s, c := net.Pipe()
+	go c.Write([]byte("GET /sub HTTP/1.1\r\n\r\n"))
+	conn := websocket.NewConn(websocket.New(s, bufio.NewReader(s), bufio.NewWriter(s)))
+	t.Cleanup(func() {
+		s.Close()
+		c.Close()
+	})
+	return conn, c
+}
+
+// roomKeys walks the room list from head to tail and checks the back links
+func roomKeys(t *testing.T, r *Room) []string {
+	t.Helper()
+	var (
+		keys []string
+		prev *member
+	)
+	for m := r.next; m != nil; m = m.next {
+		if m.prev != prev {
+			t.Fatalf("room(%s) key(%s) broken prev link", r.ID, m.ch.key)
+		}
+		keys = append(keys, m.ch.key)
+		prev = m
+	}
+	return keys
+}
+
+func TestRoomDel(t *testing.T) {
+	r := NewRoom("live://1000")
+	members := make(map[string]*member)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		m, err := r.Put(newTestChannel(key, ""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		members[key] = m
+	}
+	if keys := roomKeys(t, r); !reflect.DeepEqual(keys, []string{"d", "c", "b", "a"}) {
+		t.Fatalf("room keys %v", keys)
+	}
+
+	for _, c := range []struct {
+		key  string
+		keys []string
+	}{
+		{"c", []string{"d", "b", "a"}}, // middle
+		{"d", []string{"b", "a"}},      // head
+		{"a", []string{"b"}},           // tail
+	} {
+		if r.Del(members[c.key]) {
+			t.Fatalf("room dropped after del(%s)", c.key)
+		}
+		if keys := roomKeys(t, r); !reflect.DeepEqual(keys, c.keys) {
+			t.Fatalf("del(%s) room keys %v, want %v", c.key, keys, c.keys)
+		}
+		if online := r.Online(); online != int32(len(c.keys)) {
+			t.Fatalf("del(%s) online %d, want %d", c.key, online, len(c.keys))
+		}
+	}
+
+	if !r.Del(members["b"]) {
+		t.Fatal("empty room not dropped")
+	}
+	if _, err := r.Put(newTestChannel("e", "")); err != ErrRoomDropped {
+		t.Fatalf("put into dropped room - (%v)", err)
+	}
+}
+
+func TestBucketRooms(t *testing.T) {
+	b := NewBucket(&conf.Bucket{Channel: 8, Room: 8})
+	ch1 := newTestChannel("k1", "r1")
+	ch2 := newTestChannel("k2", "r1")
+	for _, ch := range []*Channel{ch1, ch2} {
+		if err := b.put(ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := b.sub(ch1, []string{"r2", "r2", "r1"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch1.rooms) != 2 {
+		t.Fatalf("duplicate subs joined rooms %v", ch1.rooms)
+	}
+	if rooms := b.rooms(); !reflect.DeepEqual(rooms, map[string]int32{"r1": 2, "r2": 1}) {
+		t.Fatalf("rooms online %v", rooms)
+	}
+
+	b.unsub(ch1, []string{"r2"})
+	if b.room("r2") != nil {
+		t.Fatal("empty room r2 not reclaimed")
+	}
+
+	if err := b.changeRoom(ch2, "r3"); err != nil {
+		t.Fatal(err)
+	}
+	if rooms := b.rooms(); !reflect.DeepEqual(rooms, map[string]int32{"r1": 1, "r3": 1}) {
+		t.Fatalf("rooms online after change room %v", rooms)
+	}
+
+	old := b.room("r1")
+	if !b.del(ch1) {
+		t.Fatal("del channel of its own key")
+	}
+	if b.room("r1") != nil {
+		t.Fatal("empty room r1 not reclaimed")
+	}
+
+	if err := b.changeRoom(ch2, "r1"); err != nil {
+		t.Fatal(err)
+	}
+	room := b.room("r1")
+	if room == nil || room == old {
+		t.Fatal("room r1 not re-created")
+	}
+	if room.Online() != 1 || b.room("r3") != nil {
+		t.Fatalf("rooms online after rejoin %v", b.rooms())
+	}
+	if n := b.broadcastRoom("r1", &protocol.Proto{Op: protocol.OpRaw}); n != 1 {
+		t.Fatalf("broadcast room written %d", n)
+	}
+}
+
+func TestBucketTakeover(t *testing.T) {
+	b := NewBucket(&conf.Bucket{Channel: 8, Room: 8})
+	old := newTestChannel("k", "r1")
+	var client net.Conn
+	old.conn, client = newTestConn(t)
+	if err := b.put(old); err != nil {
+		t.Fatal(err)
+	}
+
+	ch := newTestChannel("k", "r2")
+	if err := b.put(ch); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := b.get("k"); got != ch {
+		t.Fatal("key not taken over")
+	}
+	if len(old.rooms) != 0 || b.room("r1") != nil {
+		t.Fatal("replaced channel still in its rooms")
+	}
+
+	// the replaced client gets the disconnect and the kicked close frame
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	frames, _ := ioutil.ReadAll(client)
+	if !bytes.Contains(frames, []byte(KickReplaced)) || !bytes.Contains(frames, []byte{0x0f, 0xa3}) {
+		t.Fatalf("replaced client got frames %x", frames)
+	}
+
+	if b.del(old) {
+		t.Fatal("del replaced channel returns true")
+	}
+	if got, _ := b.get("k"); got != ch || b.size() != 1 {
+		t.Fatal("del replaced channel removed the new one")
+	}
+
+	if !b.del(ch) {
+		t.Fatal("del channel of its own key")
+	}
+	if _, err := b.get("k"); err == nil || b.size() != 0 || b.room("r2") != nil {
+		t.Fatal("channel not removed")
+	}
+}
@@ -12,9 +12,6 @@ import (
 )
 
 type WSServer struct {
-	conn   net.Conn
-	Reader *bufio.Reader
-	Writer *bufio.Writer
 	Server *Scratcher
 }
 
@@ -64,52 +61,73 @@ func (w *WSServer) accept(s *Scratcher, l *net.TCPListener) {
 			return
 		}
 
-		w.conn = conn
-		w.Reader = bufio.NewReader(conn)
-		w.Writer = bufio.NewWriter(conn)
-		go w.tcp()
+		go w.tcp(conn)
 	}
 }
 
-func (w *WSServer) tcp() {
+func (w *WSServer) tcp(c net.Conn) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	wb := websocket.New(w.conn, w.Reader, w.Writer)
+	// the conn is closed if the client keeps silent until the timer expires,
+	// which fails the read below and removes the channel
+	wheel := w.Server.Wheel()
+	hb := time.Duration(w.Server.Conf.WebSocket.HandshakeTimeout)
+	if hb <= 0 {
+		hb = DefaultHandshakeTimeout
+	}
+	t := wheel.Add(hb, func() {
+		c.Close()
+	})
+	defer wheel.Del(t)
+
+	wb := websocket.New(c, bufio.NewReader(c), bufio.NewWriter(c))
 
-	if wb.Request.Method != "get" || wb.Request.RequestURI != "/sub" {
+	if wb.Request == nil || wb.Request.Method != "get" || wb.Request.RequestURI != "/sub" {
 		wb.Close()
+		return
 	}
 
 	if err := wb.Upgrade(); err != nil {
 		log.Errorf("fail to upgrade - (%s)", err)
 		wb.Close()
+		return
 	}
 
 	conn := websocket.NewConn(wb)
-	p := protocol.NewProtocol(w.Server)
+	p := protocol.NewProtocol()
 
 	ch := NewChannel()
 
 	//cat 进行认证 权限认证
 	var (
-		err error
-		hb  time.Duration
+		err       error
+		b         *Bucket
+		sess      *session
+		heartbeat int64
 	)
-	ch.mid, ch.key, ch.roomID, _, err = w.Server.Auth(ctx, p, conn)
-	ch.q = make(chan string, 8)
+	ch.mid, ch.key, ch.roomID, heartbeat, err = w.Server.Auth(ctx, p, conn)
+	ch.q = make(chan *protocol.Proto, 8)
 	ch.conn = conn
 
 	if err != nil {
+		log.Errorf("auth conn(%s) error - (%v)", c.RemoteAddr(), err)
+		w.Server.authFailed(p, conn, err)
 		goto failed
 	}
 
+	if hb = time.Duration(heartbeat); hb <= 0 {
+		hb = DefaultHeartbeat
+	}
+	wheel.Set(t, hb)
+
 	//conn put into bucket
-	if err := w.Server.Bucket.put(ch); err != nil {
+	b = w.Server.Bucket(ch.key)
+	if err := b.put(ch); err != nil {
 		goto failed
 	}
 
-	hb = w.Server.RandServerHeartbeat()
+	sess = newSession(w.Server, b, ch)
 
 	go w.Server.Dispatch(ctx, ch)
 
@@ -117,25 +135,17 @@ func (w *WSServer) tcp() {
 		if err := p.ReadWebsocket(conn); err != nil {
 			goto failed
 		}
+		wheel.Set(t, hb)
 
-		// refresh  session map
-		if p.Op == protocol.OpHeartbeat {
-			if now := time.Now(); now.Sub(w.Server.LastHeartbeat) > hb {
-				if err := p.OpHeartbeat(ch.mid, ch.key, w.Server.ServerID); err == nil {
-					w.Server.LastHeartbeat = now
-				}
-			}
-		}
-
-		if err := w.Server.Handle(p); err != nil {
+		if err := w.Server.Handle(p, sess); err != nil {
 			goto failed
 		}
 	}
 
 failed:
 	conn.Close()
-}
-
-func (w *WSServer) Close() {
-	w.conn.Close()
+	// cat maps the key once identified, unless the channel was taken over by the key connecting again
+	if ch.key != "" && (b == nil || b.del(ch)) {
+		w.Server.Disconnect(context.Background(), ch.mid, ch.key)
+	}
 }
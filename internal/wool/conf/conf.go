@@ -0,0 +1,89 @@
+package conf
+
+import (
+	"dube/pkg/otime"
+	"flag"
+	"github.com/BurntSushi/toml"
+	"os"
+	"time"
+)
+
+type Options struct {
+	Env       *Env
+	Kafka     *Kafka
+	Scratcher *Scratcher
+	Registry  *Registry
+}
+
+type Kafka struct {
+	Topic   string
+	Group   string
+	Brokers []string
+}
+
+type Scratcher struct {
+	Dial        otime.Duration
+	Timeout     otime.Duration
+	RoutineChan int
+	RoutineSize int
+	Batch       int
+	Retry       int
+	Servers     map[string]string //server id -> rpc addr
+}
+
+type Registry struct {
+	Network  string
+	Addr     string //"" static scratcher servers only
+	Auth     string
+	Timeout  otime.Duration
+	Interval otime.Duration
+}
+
+type Env struct {
+	Region string
+	Zone   string
+	Host   string
+}
+
+var (
+	confPath   string
+	region     string
+	zone       string
+	host       string
+	defHost, _ = os.Hostname()
+)
+
+func init() {
+	flag.StringVar(&confPath, "conf", "cmd/wool/wool.toml", "default config path.")
+	flag.StringVar(&region, "region", os.Getenv("REGION"), "available region. or use REGION env variable.etc: hn")
+	flag.StringVar(&zone, "zone", os.Getenv("ZONE"), "available zone. or use ZONE env variable.etc: hn001")
+	flag.StringVar(&host, "host", defHost, "server id.must be unique. default machine name")
+}
+
+func Default() (*Options, error) {
+	options := &Options{
+		Env: &Env{
+			Region: region,
+			Zone:   zone,
+			Host:   host,
+		},
+		Scratcher: &Scratcher{
+			Dial:        otime.Duration(time.Second),
+			Timeout:     otime.Duration(time.Second),
+			RoutineChan: 1024,
+			RoutineSize: 32,
+			Batch:       64,
+			Retry:       3,
+		},
+		Registry: &Registry{
+			Network:  "tcp",
+			Timeout:  otime.Duration(time.Millisecond * 500),
+			Interval: otime.Duration(time.Second * 5),
+		},
+	}
+	_, err := toml.DecodeFile(confPath, &options)
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
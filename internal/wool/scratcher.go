@@ -0,0 +1,335 @@
+package wool
+
+import (
+	"bytes"
+	"context"
+	pb "dube/internal/protocol/scratcher"
+	"dube/internal/wool/conf"
+	"errors"
+	log "github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	grpcInitialWindowSize     = 1 << 24
+	grpcInitialConnWindowSize = 1 << 24
+	grpcMaxCallMsgSize        = 1 << 24
+	grpcMaxSendMsgSize        = 1 << 24
+	grpcBackoffMaxDelay       = 3 * time.Second
+	grpcKeepAliveTime         = 10 * time.Second
+	grpcKeepAliveTimeout      = 3 * time.Second
+)
+
+const (
+	retryBackoff = 100 * time.Millisecond
+)
+
+var (
+	ErrScratcherClosed = errors.New("wool: scratcher closed")
+)
+
+// pushTask is a queued push, the dones of the merged pushes are called once the delivery is attempted
+type pushTask struct {
+	req   *pb.PushMsgReq
+	dones []func()
+}
+
+func (t *pushTask) finish() {
+	for _, done := range t.dones {
+		done()
+	}
+}
+
+type roomTask struct {
+	req  *pb.BroadcastRoomReq
+	done func()
+}
+
+type broadcastTask struct {
+	req  *pb.BroadcastReq
+	done func()
+}
+
+// Scratcher delivers messages to one scratcher server through routines
+type Scratcher struct {
+	server        string
+	addr          string
+	client        pb.ScratcherClient
+	conn          *grpc.ClientConn
+	pushChan      []chan *pushTask
+	roomChan      []chan *roomTask
+	broadcastChan chan *broadcastTask
+	routineSize   uint64
+	batch         int
+	retry         int
+	timeout       time.Duration
+	lock          sync.RWMutex
+	closed        bool
+	routines      sync.WaitGroup
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+func NewScratcher(server, addr string, c *conf.Scratcher) (*Scratcher, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Dial))
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		[]grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithInitialWindowSize(grpcInitialWindowSize),
+			grpc.WithInitialConnWindowSize(grpcInitialConnWindowSize),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxCallMsgSize)),
+			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcMaxSendMsgSize)),
+			grpc.WithBackoffMaxDelay(grpcBackoffMaxDelay),
+			grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time:                grpcKeepAliveTime,
+				Timeout:             grpcKeepAliveTimeout,
+				PermitWithoutStream: true,
+			}),
+		}...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scratcher{
+		server:        server,
+		addr:          addr,
+		client:        pb.NewScratcherClient(conn),
+		conn:          conn,
+		pushChan:      make([]chan *pushTask, c.RoutineSize),
+		roomChan:      make([]chan *roomTask, c.RoutineSize),
+		broadcastChan: make(chan *broadcastTask, c.RoutineChan),
+		routineSize:   uint64(c.RoutineSize),
+		batch:         c.Batch,
+		retry:         c.Retry,
+		timeout:       time.Duration(c.Timeout),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	for i := 0; i < c.RoutineSize; i++ {
+		s.pushChan[i] = make(chan *pushTask, c.RoutineChan)
+		s.roomChan[i] = make(chan *roomTask, c.RoutineChan)
+		s.routines.Add(1)
+		go s.process(s.pushChan[i], s.roomChan[i], s.broadcastChan)
+	}
+	return s, nil
+}
+
+func (s *Scratcher) routine(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64() % s.routineSize
+}
+
+// Push queue the message, the keys are split by routine so messages of the same key keep their order,
+// done is called once the delivery is attempted or the message is dropped
+func (s *Scratcher) Push(ctx context.Context, req *pb.PushMsgReq, done func()) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		done()
+		return ErrScratcherClosed
+	}
+
+	var idxs []uint64
+	keys := make(map[uint64][]string)
+	for _, key := range req.Keys {
+		idx := s.routine(key)
+		if _, ok := keys[idx]; !ok {
+			idxs = append(idxs, idx)
+		}
+		keys[idx] = append(keys[idx], key)
+	}
+	if len(idxs) == 0 {
+		done()
+		return nil
+	}
+
+	// done once every split of the message is finished
+	remain := int32(len(idxs))
+	finish := func() {
+		if atomic.AddInt32(&remain, -1) == 0 {
+			done()
+		}
+	}
+	for i, idx := range idxs {
+		t := &pushTask{req: &pb.PushMsgReq{Keys: keys[idx], Op: req.Op, Body: req.Body}, dones: []func(){finish}}
+		select {
+		case s.pushChan[idx] <- t:
+			continue
+		case <-ctx.Done():
+			err := ctx.Err()
+			for ; i < len(idxs); i++ {
+				finish()
+			}
+			return err
+		case <-s.ctx.Done():
+			for ; i < len(idxs); i++ {
+				finish()
+			}
+			return ErrScratcherClosed
+		}
+	}
+	return nil
+}
+
+// BroadcastRoom queue the message, messages of the same room keep their order,
+// done is called once the delivery is attempted or the message is dropped
+func (s *Scratcher) BroadcastRoom(ctx context.Context, req *pb.BroadcastRoomReq, done func()) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		done()
+		return ErrScratcherClosed
+	}
+	select {
+	case s.roomChan[s.routine(req.RoomID)] <- &roomTask{req: req, done: done}:
+		return nil
+	case <-ctx.Done():
+		done()
+		return ctx.Err()
+	case <-s.ctx.Done():
+		done()
+		return ErrScratcherClosed
+	}
+}
+
+func (s *Scratcher) Broadcast(ctx context.Context, req *pb.BroadcastReq, done func()) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		done()
+		return ErrScratcherClosed
+	}
+	select {
+	case s.broadcastChan <- &broadcastTask{req: req, done: done}:
+		return nil
+	case <-ctx.Done():
+		done()
+		return ctx.Err()
+	case <-s.ctx.Done():
+		done()
+		return ErrScratcherClosed
+	}
+}
+
+func (s *Scratcher) process(pushChan chan *pushTask, roomChan chan *roomTask, broadcastChan chan *broadcastTask) {
+	defer s.routines.Done()
+	for {
+		select {
+		case t := <-pushChan:
+			for _, b := range s.batchPush(t, pushChan) {
+				s.invoke("PushMsg", func(ctx context.Context) error {
+					resp, err := s.client.PushMsg(ctx, b.req)
+					if err == nil && len(resp.GetOffline()) > 0 {
+						log.Infof("scratcher(%s) offline keys(%v)", s.server, resp.GetOffline())
+					}
+					if err == nil && len(resp.GetDropped()) > 0 {
+						log.Errorf("scratcher(%s) dropped keys(%v)", s.server, resp.GetDropped())
+					}
+					return err
+				})
+				b.finish()
+			}
+		case t := <-roomChan:
+			s.invoke("BroadcastRoom", func(ctx context.Context) error {
+				_, err := s.client.BroadcastRoom(ctx, t.req)
+				return err
+			})
+			t.done()
+		case t := <-broadcastChan:
+			s.invoke("Broadcast", func(ctx context.Context) error {
+				_, err := s.client.Broadcast(ctx, t.req)
+				return err
+			})
+			t.done()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// batchPush merges the following queued requests having the same op and body into the request,
+// it stops at the first one which can't be merged to keep the order of the messages
+func (s *Scratcher) batchPush(t *pushTask, ch chan *pushTask) []*pushTask {
+	for i := 1; i < s.batch; i++ {
+		var r *pushTask
+		select {
+		case r = <-ch:
+		default:
+			return []*pushTask{t}
+		}
+
+		if t.req.Op != r.req.Op || !bytes.Equal(t.req.Body, r.req.Body) {
+			return []*pushTask{t, r}
+		}
+		t.req.Keys = append(t.req.Keys, r.req.Keys...)
+		t.dones = append(t.dones, r.dones...)
+	}
+	return []*pushTask{t}
+}
+
+// invoke calls the rpc, retry with backoff on failure
+func (s *Scratcher) invoke(method string, fn func(ctx context.Context) error) {
+	var err error
+	for i := 0; i <= s.retry; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(time.Duration(i) * retryBackoff):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+		err = fn(ctx)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	log.Errorf("scratcher(%s) %s error after %d retries - (%v)", s.server, method, s.retry, err)
+}
+
+func (s *Scratcher) Addr() string {
+	return s.addr
+}
+
+// Close stops the routines, the messages still queued are dropped
+func (s *Scratcher) Close() error {
+	s.cancel()
+	s.lock.Lock()
+	s.closed = true
+	s.lock.Unlock()
+
+	s.routines.Wait()
+	for i := range s.pushChan {
+		s.drain(s.pushChan[i], s.roomChan[i])
+	}
+	return s.conn.Close()
+}
+
+// drain calls the done of the messages left in the channels
+func (s *Scratcher) drain(pushChan chan *pushTask, roomChan chan *roomTask) {
+	for {
+		select {
+		case t := <-pushChan:
+			t.finish()
+		case t := <-roomChan:
+			t.done()
+		case t := <-s.broadcastChan:
+			t.done()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package wool
+
+import (
+	"context"
+	pb "dube/internal/protocol/scratcher"
+	wpb "dube/internal/protocol/wool"
+	"dube/internal/wool/conf"
+	"dube/pkg/queue"
+	"dube/pkg/registry"
+	"errors"
+	log "github.com/golang/glog"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"time"
+)
+
+var (
+	ErrScratcherNotFound = errors.New("wool: scratcher server not found")
+	ErrPushMsgType       = errors.New("wool: unknown push msg type")
+	ErrPushMsgArg        = errors.New("wool: push msg arg error")
+)
+
+const (
+	// messages consumed while the delivery of an earlier one is pending
+	pendingSize = 1024
+)
+
+// pending is a consumed message, it's committed after done is closed
+type pending struct {
+	msg  *queue.Message
+	done chan struct{}
+}
+
+type Wool struct {
+	c          *conf.Options
+	consumer   queue.Consumer
+	registry   registry.Registry
+	lock       sync.RWMutex
+	scratchers map[string]*Scratcher
+	pendings   chan *pending
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func New(c *conf.Options, consumer queue.Consumer) *Wool {
+	w := &Wool{
+		c:          c,
+		consumer:   consumer,
+		scratchers: make(map[string]*Scratcher, len(c.Scratcher.Servers)),
+		pendings:   make(chan *pending, pendingSize),
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	for server, addr := range c.Scratcher.Servers {
+		s, err := NewScratcher(server, addr, c.Scratcher)
+		if err != nil {
+			log.Errorf("fail to dial scratcher(%s) addr(%s) - (%v)", server, addr, err)
+			continue
+		}
+		w.scratchers[server] = s
+	}
+
+	if c.Registry != nil && c.Registry.Addr != "" {
+		pool := registry.NewRedisPool(c.Registry.Network, c.Registry.Addr, c.Registry.Auth, time.Duration(c.Registry.Timeout))
+		w.registry = registry.NewRedis(pool, registry.AppScratcher, 0)
+		go w.watchScratchers(registry.Watch(w.ctx, w.registry, time.Duration(c.Registry.Interval)))
+	}
+
+	go w.consume()
+	go w.commitproc()
+	return w
+}
+
+// allScratchers returns the scratchers by server, the map must not be modified
+func (w *Wool) allScratchers() map[string]*Scratcher {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.scratchers
+}
+
+// watchScratchers keeps the scratchers in line with the nodes registered, the static servers
+// are kept unless a node registers the same rpc addr
+func (w *Wool) watchScratchers(ch <-chan []*registry.Node) {
+	for nodes := range ch {
+		scratchers, left := registry.Merge(w.allScratchers(), nodes, w.c.Scratcher.Servers, func(server, addr string) (*Scratcher, error) {
+			s, err := NewScratcher(server, addr, w.c.Scratcher)
+			if err != nil {
+				log.Errorf("fail to dial scratcher(%s) addr(%s) - (%v)", server, addr, err)
+				return nil, err
+			}
+			log.Infof("scratcher(%s) addr(%s) joined", server, addr)
+			return s, nil
+		})
+
+		w.lock.Lock()
+		w.scratchers = scratchers
+		w.lock.Unlock()
+
+		for _, s := range left {
+			log.Infof("scratcher(%s) addr(%s) left", s.server, s.addr)
+			s.Close()
+		}
+	}
+}
+
+func (w *Wool) consume() {
+	for {
+		msg, err := w.consumer.Consume(w.ctx)
+		if err != nil {
+			if err == queue.ErrClosed || w.ctx.Err() != nil {
+				return
+			}
+			log.Errorf("consume error - (%v)", err)
+			continue
+		}
+
+		wg := new(sync.WaitGroup)
+		m := new(wpb.PushMsg)
+		if err = proto.Unmarshal(msg.Value, m); err != nil {
+			log.Errorf("proto.Unmarshal(%s) error - (%v)", msg.Value, err)
+		} else if err = w.push(m, wg); err != nil {
+			log.Errorf("push(%v) error - (%v)", m, err)
+		}
+
+		p := &pending{msg: msg, done: make(chan struct{})}
+		go func() {
+			wg.Wait()
+			close(p.done)
+		}()
+		select {
+		case w.pendings <- p:
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// commitproc commits the messages in the consumed order once their delivery is attempted,
+// the messages pending on exit are consumed again after restart
+func (w *Wool) commitproc() {
+	for {
+		select {
+		case p := <-w.pendings:
+			select {
+			case <-p.done:
+			case <-w.ctx.Done():
+				return
+			}
+			if err := w.consumer.Commit(w.ctx, p.msg); err != nil {
+				log.Errorf("commit(%s) error - (%v)", p.msg.Key, err)
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// push queue the message to the scratchers, wg is done once the deliveries are attempted
+func (w *Wool) push(m *wpb.PushMsg, wg *sync.WaitGroup) error {
+	scratchers := w.allScratchers()
+	switch m.GetType() {
+	case wpb.PushMsg_PUSH:
+		if len(m.GetKeys()) == 0 {
+			return ErrPushMsgArg
+		}
+		req := &pb.PushMsgReq{Keys: m.GetKeys(), Op: m.GetOp(), Body: m.GetBody()}
+		s, ok := scratchers[m.GetServer()]
+		if !ok {
+			if w.registry == nil {
+				return ErrScratcherNotFound
+			}
+			wg.Add(1)
+			go w.awaitPush(m.GetServer(), req, wg.Done)
+			return nil
+		}
+		wg.Add(1)
+		return s.Push(w.ctx, req, wg.Done)
+	case wpb.PushMsg_ROOM:
+		for _, s := range scratchers {
+			wg.Add(1)
+			if err := s.BroadcastRoom(w.ctx, &pb.BroadcastRoomReq{RoomID: m.GetRoom(), Op: m.GetOp(), Body: m.GetBody()}, wg.Done); err != nil {
+				return err
+			}
+		}
+	case wpb.PushMsg_BROADCAST:
+		speed := m.GetSpeed()
+		if n := int32(len(scratchers)); n > 0 && speed > 0 {
+			if speed /= n; speed == 0 {
+				speed = 1
+			}
+		}
+		for _, s := range scratchers {
+			wg.Add(1)
+			if err := s.Broadcast(w.ctx, &pb.BroadcastReq{Op: m.GetOp(), Body: m.GetBody(), Speed: speed}, wg.Done); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrPushMsgType
+	}
+	return nil
+}
+
+// awaitPush waits a few registry intervals for the server not discovered yet, then queue the push to it
+func (w *Wool) awaitPush(server string, req *pb.PushMsgReq, done func()) {
+	for i := 0; i < w.c.Scratcher.Retry; i++ {
+		select {
+		case <-time.After(time.Duration(w.c.Registry.Interval)):
+		case <-w.ctx.Done():
+			done()
+			return
+		}
+		if s, ok := w.allScratchers()[server]; ok {
+			if err := s.Push(w.ctx, req, done); err != nil {
+				log.Errorf("push keys(%v) to scratcher(%s) error - (%v)", req.GetKeys(), server, err)
+			}
+			return
+		}
+	}
+	log.Errorf("push keys(%v) error - (%v)", req.GetKeys(), ErrScratcherNotFound)
+	done()
+}
+
+func (w *Wool) Close() error {
+	w.cancel()
+	if w.registry != nil {
+		w.registry.Close()
+	}
+	for _, s := range w.allScratchers() {
+		s.Close()
+	}
+	return w.consumer.Close()
+}
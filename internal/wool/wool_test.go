@@ -0,0 +1,191 @@
+package wool
+
+import (
+	"context"
+	pb "dube/internal/protocol/scratcher"
+	wpb "dube/internal/protocol/wool"
+	"dube/internal/wool/conf"
+	"dube/pkg/otime"
+	"dube/pkg/queue"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"testing"
+	"time"
+)
+
+type fakeScratcher struct {
+	pb.UnimplementedScratcherServer
+	release chan struct{}
+	pushed  chan *pb.PushMsgReq
+	rooms   chan *pb.BroadcastRoomReq
+}
+
+func (f *fakeScratcher) PushMsg(ctx context.Context, req *pb.PushMsgReq) (*pb.PushMsgResp, error) {
+	<-f.release
+	f.pushed <- req
+	return &pb.PushMsgResp{}, nil
+}
+
+func (f *fakeScratcher) BroadcastRoom(ctx context.Context, req *pb.BroadcastRoomReq) (*pb.BroadcastRoomResp, error) {
+	<-f.release
+	f.rooms <- req
+	return &pb.BroadcastRoomResp{}, nil
+}
+
+// commitConsumer records the commits of the memory queue
+type commitConsumer struct {
+	*queue.Memory
+	commits chan *queue.Message
+}
+
+func (c *commitConsumer) Commit(ctx context.Context, msg *queue.Message) error {
+	c.commits <- msg
+	return nil
+}
+
+func newTestWool(t *testing.T, f *fakeScratcher) (*Wool, *queue.Memory, *commitConsumer) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterScratcherServer(srv, f)
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	c := &conf.Options{
+		Scratcher: &conf.Scratcher{
+			Dial:        otime.Duration(time.Second),
+			Timeout:     otime.Duration(time.Second),
+			RoutineChan: 16,
+			RoutineSize: 2,
+			Batch:       4,
+			Retry:       1,
+			Servers:     map[string]string{"s1": l.Addr().String()},
+		},
+		Registry: &conf.Registry{},
+	}
+	m := queue.NewMemory(16)
+	consumer := &commitConsumer{Memory: m, commits: make(chan *queue.Message, 16)}
+	w := New(c, consumer)
+	t.Cleanup(func() { w.Close() })
+	return w, m, consumer
+}
+
+func publish(t *testing.T, m *queue.Memory, msg *wpb.PushMsg) {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Publish(context.Background(), []byte(msg.Server), b); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWoolCommitAfterDelivery(t *testing.T) {
+	f := &fakeScratcher{
+		release: make(chan struct{}),
+		pushed:  make(chan *pb.PushMsgReq, 4),
+		rooms:   make(chan *pb.BroadcastRoomReq, 4),
+	}
+	_, m, consumer := newTestWool(t, f)
+
+	publish(t, m, &wpb.PushMsg{Type: wpb.PushMsg_PUSH, Op: 1000, Server: "s1", Keys: []string{"k1"}, Body: []byte("hi")})
+	publish(t, m, &wpb.PushMsg{Type: wpb.PushMsg_ROOM, Op: 1000, Room: "live://1000", Body: []byte("hi")})
+
+	select {
+	case msg := <-consumer.commits:
+		t.Fatalf("commit(%s) before delivery", msg.Key)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(f.release)
+	select {
+	case req := <-f.pushed:
+		if len(req.Keys) != 1 || req.Keys[0] != "k1" || string(req.Body) != "hi" {
+			t.Fatalf("pushed %v", req)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("push not delivered")
+	}
+	select {
+	case req := <-f.rooms:
+		if req.RoomID != "live://1000" {
+			t.Fatalf("broadcast room %v", req)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("room not delivered")
+	}
+
+	for _, key := range []string{"s1", ""} {
+		select {
+		case msg := <-consumer.commits:
+			if string(msg.Key) != key {
+				t.Fatalf("commit(%s) out of order, want (%s)", msg.Key, key)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("commit(%s) missing", key)
+		}
+	}
+}
+
+func TestWoolCommitUnknownServer(t *testing.T) {
+	f := &fakeScratcher{
+		release: make(chan struct{}),
+		pushed:  make(chan *pb.PushMsgReq, 4),
+	}
+	close(f.release)
+	_, m, consumer := newTestWool(t, f)
+
+	publish(t, m, &wpb.PushMsg{Type: wpb.PushMsg_PUSH, Op: 1000, Server: "missing", Keys: []string{"k1"}})
+
+	select {
+	case msg := <-consumer.commits:
+		if string(msg.Key) != "missing" {
+			t.Fatalf("commit(%s)", msg.Key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message of unknown server not committed")
+	}
+	select {
+	case req := <-f.pushed:
+		t.Fatalf("pushed %v to unknown server", req)
+	default:
+	}
+}
+
+func TestWoolPushOrder(t *testing.T) {
+	f := &fakeScratcher{
+		release: make(chan struct{}),
+		pushed:  make(chan *pb.PushMsgReq, 8),
+	}
+	_, m, consumer := newTestWool(t, f)
+
+	for _, body := range []string{"x", "y", "x"} {
+		publish(t, m, &wpb.PushMsg{Type: wpb.PushMsg_PUSH, Op: 1000, Server: "s1", Keys: []string{"k1"}, Body: []byte(body)})
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-consumer.commits:
+			t.Fatal("commit before delivery")
+		case <-time.After(30 * time.Millisecond):
+		}
+	}
+
+	close(f.release)
+	var bodies []string
+	for len(bodies) < 3 {
+		select {
+		case req := <-f.pushed:
+			for range req.Keys {
+				bodies = append(bodies, string(req.Body))
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("pushed %v", bodies)
+		}
+	}
+	if bodies[0] != "x" || bodies[1] != "y" || bodies[2] != "x" {
+		t.Fatalf("pushed out of order %v", bodies)
+	}
+}
@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrMalformed    = errors.New("jwt: malformed token")
+	ErrAlgorithm    = errors.New("jwt: unsupported algorithm")
+	ErrSignature    = errors.New("jwt: invalid signature")
+	ErrExpired      = errors.New("jwt: token expired")
+	ErrNotValidYet  = errors.New("jwt: token not valid yet")
+	ErrAudience     = errors.New("jwt: invalid audience")
+	ErrNoExpiration = errors.New("jwt: expiration missing")
+)
+
+var (
+	enc = base64.RawURLEncoding
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Audience is a string or an array of strings
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(a))
+}
+
+func (a Audience) Contains(aud string) bool {
+	for _, s := range a {
+		if s == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims are the registered claims, times are unix seconds
+type Claims struct {
+	Subject   string   `json:"sub,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
+
+// Valid checks the token has not expired and is issued to aud if it's not "", leeway is allowed for clock skew
+func (c *Claims) Valid(now time.Time, aud string, leeway time.Duration) error {
+	if c.ExpiresAt == 0 {
+		return ErrNoExpiration
+	}
+	if now.Add(-leeway).Unix() >= c.ExpiresAt {
+		return ErrExpired
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Unix() < c.NotBefore {
+		return ErrNotValidYet
+	}
+	if aud != "" && !c.Audience.Contains(aud) {
+		return ErrAudience
+	}
+	return nil
+}
+
+// Sign returns the HS256 token of the claims
+func Sign(claims interface{}, secret []byte) (string, error) {
+	h, err := json.Marshal(&header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signing := enc.EncodeToString(h) + "." + enc.EncodeToString(p)
+	return signing + "." + enc.EncodeToString(sign([]byte(signing), secret)), nil
+}
+
+// Parse verifies the HS256 signature of the token and decodes its payload into claims,
+// the claims are not validated
+func Parse(token []byte, secret []byte, claims interface{}) error {
+	parts := bytes.Split(token, []byte("."))
+	if len(parts) != 3 {
+		return ErrMalformed
+	}
+
+	h := new(header)
+	if err := decode(parts[0], h); err != nil {
+		return err
+	}
+	if h.Alg != "HS256" {
+		return ErrAlgorithm
+	}
+
+	sig := make([]byte, enc.DecodedLen(len(parts[2])))
+	n, err := enc.Decode(sig, parts[2])
+	if err != nil {
+		return ErrMalformed
+	}
+	if !hmac.Equal(sig[:n], sign(token[:len(parts[0])+1+len(parts[1])], secret)) {
+		return ErrSignature
+	}
+	return decode(parts[1], claims)
+}
+
+func decode(part []byte, v interface{}) error {
+	b := make([]byte, enc.DecodedLen(len(part)))
+	n, err := enc.Decode(b, part)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err = json.Unmarshal(b[:n], v); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
+
+func sign(signing, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(signing)
+	return mac.Sum(nil)
+}
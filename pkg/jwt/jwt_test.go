@@ -0,0 +1,68 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+)
+
+type testClaims struct {
+	Claims
+	Mid int64 `json:"mid"`
+}
+
+func TestSignParse(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+
+	token, err := Sign(&testClaims{Claims: Claims{Audience: Audience{"dube"}, ExpiresAt: now.Add(time.Minute).Unix()}, Mid: 123}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := new(testClaims)
+	if err = Parse([]byte(token), secret, c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Mid != 123 {
+		t.Fatalf("parse mid %d", c.Mid)
+	}
+	if err = c.Valid(now, "dube", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Valid(now, "other", 0); err != ErrAudience {
+		t.Fatalf("valid other audience - (%v)", err)
+	}
+	if err = c.Valid(now.Add(2*time.Minute), "dube", 0); err != ErrExpired {
+		t.Fatalf("valid expired - (%v)", err)
+	}
+	if err = c.Valid(now.Add(2*time.Minute), "dube", 2*time.Minute); err != nil {
+		t.Fatalf("valid within leeway - (%v)", err)
+	}
+
+	if err = Parse([]byte(token), []byte("other"), c); err != ErrSignature {
+		t.Fatalf("parse with other secret - (%v)", err)
+	}
+	if err = Parse([]byte(token[:len(token)-2]), secret, c); err != ErrSignature && err != ErrMalformed {
+		t.Fatalf("parse truncated token - (%v)", err)
+	}
+	if err = Parse([]byte("a.b"), secret, c); err != ErrMalformed {
+		t.Fatalf("parse malformed token - (%v)", err)
+	}
+}
+
+func TestAudience(t *testing.T) {
+	var a Audience
+	if err := a.UnmarshalJSON([]byte(`"dube"`)); err != nil || !a.Contains("dube") {
+		t.Fatalf("audience string %v - (%v)", a, err)
+	}
+	if err := a.UnmarshalJSON([]byte(`["a","dube"]`)); err != nil || !a.Contains("dube") || len(a) != 2 {
+		t.Fatalf("audience array %v - (%v)", a, err)
+	}
+}
+
+func TestAlgorithm(t *testing.T) {
+	// {"alg":"none"}.{"mid":1}.
+	if err := Parse([]byte("eyJhbGciOiJub25lIn0.eyJtaWQiOjF9."), []byte("secret"), new(testClaims)); err != ErrAlgorithm {
+		t.Fatalf("parse alg none - (%v)", err)
+	}
+}
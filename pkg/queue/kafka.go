@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+)
+
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: kafka.NewWriter(kafka.WriterConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		}),
+	}
+}
+
+func (k *KafkaPublisher) Publish(ctx context.Context, key, value []byte) error {
+	return k.writer.WriteMessages(ctx, kafka.Message{Key: key, Value: value})
+}
+
+func (k *KafkaPublisher) Close() error {
+	return k.writer.Close()
+}
+
+type KafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+func NewKafkaConsumer(brokers []string, topic, group string) *KafkaConsumer {
+	return &KafkaConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   topic,
+			GroupID: group,
+		}),
+	}
+}
+
+func (k *KafkaConsumer) Consume(ctx context.Context) (*Message, error) {
+	m, err := k.reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Key: m.Key, Value: m.Value, raw: m}, nil
+}
+
+func (k *KafkaConsumer) Commit(ctx context.Context, msg *Message) error {
+	m, ok := msg.raw.(kafka.Message)
+	if !ok {
+		return nil
+	}
+	return k.reader.CommitMessages(ctx, m)
+}
+
+func (k *KafkaConsumer) Close() error {
+	return k.reader.Close()
+}
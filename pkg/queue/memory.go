@@ -0,0 +1,59 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is an in-process queue, messages are lost on exit
+type Memory struct {
+	q      chan *Message
+	done   chan struct{}
+	closed sync.Once
+}
+
+func NewMemory(size int) *Memory {
+	return &Memory{
+		q:    make(chan *Message, size),
+		done: make(chan struct{}),
+	}
+}
+
+func (m *Memory) Publish(ctx context.Context, key, value []byte) error {
+	select {
+	case <-m.done:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case m.q <- &Message{Key: key, Value: value}:
+		return nil
+	case <-m.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Memory) Consume(ctx context.Context) (*Message, error) {
+	select {
+	case msg := <-m.q:
+		return msg, nil
+	case <-m.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (m *Memory) Commit(ctx context.Context, msg *Message) error {
+	return nil
+}
+
+func (m *Memory) Close() error {
+	m.closed.Do(func() {
+		close(m.done)
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory(1)
+	ctx := context.Background()
+
+	if err := m.Publish(ctx, []byte("k"), []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := m.Publish(tctx, []byte("k"), []byte("full")); err != context.DeadlineExceeded {
+		t.Fatalf("publish to full queue - (%v)", err)
+	}
+
+	msg, err := m.Consume(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg.Key) != "k" || string(msg.Value) != "v" {
+		t.Fatalf("consume got (%s,%s)", msg.Key, msg.Value)
+	}
+
+	m.Close()
+	if _, err = m.Consume(ctx); err != ErrClosed {
+		t.Fatalf("consume closed queue - (%v)", err)
+	}
+	if err = m.Publish(ctx, nil, nil); err != ErrClosed {
+		t.Fatalf("publish closed queue - (%v)", err)
+	}
+}
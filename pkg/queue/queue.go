@@ -0,0 +1,29 @@
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrClosed = errors.New("queue: closed")
+)
+
+type Message struct {
+	Key   []byte
+	Value []byte
+	raw   interface{}
+}
+
+// Consumer reads messages of a topic, a message is consumed again after restart unless committed
+type Consumer interface {
+	Consume(ctx context.Context) (*Message, error)
+	Commit(ctx context.Context, msg *Message) error
+	Close() error
+}
+
+// Publisher writes messages into a topic, messages of the same key keep their order
+type Publisher interface {
+	Publish(ctx context.Context, key, value []byte) error
+	Close() error
+}
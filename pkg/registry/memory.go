@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process registry for a single node deployment
+type Memory struct {
+	lock   sync.RWMutex
+	ttl    time.Duration
+	nodes  map[string]*Node
+	closed bool
+}
+
+func NewMemory(ttl time.Duration) *Memory {
+	return &Memory{
+		ttl:   ttl,
+		nodes: make(map[string]*Node),
+	}
+}
+
+func (m *Memory) Register(ctx context.Context, n *Node) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+	node := *n
+	node.Updated = time.Now().UnixNano()
+	m.nodes[n.Server] = &node
+	return nil
+}
+
+func (m *Memory) Deregister(ctx context.Context, server string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+	delete(m.nodes, server)
+	return nil
+}
+
+func (m *Memory) Fetch(ctx context.Context) ([]*Node, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+	expired := time.Now().Add(-m.ttl).UnixNano()
+	nodes := make([]*Node, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		if n.Updated > expired {
+			node := *n
+			nodes = append(nodes, &node)
+		}
+	}
+	sortNodes(nodes)
+	return nodes, nil
+}
+
+func (m *Memory) Close() error {
+	m.lock.Lock()
+	m.closed = true
+	m.lock.Unlock()
+	return nil
+}
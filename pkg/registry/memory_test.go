@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory(50 * time.Millisecond)
+	ctx := context.Background()
+
+	if err := m.Register(ctx, &Node{Server: "b", Weight: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Register(ctx, &Node{Server: "a", Weight: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := m.Fetch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].Server != "a" || nodes[1].Server != "b" {
+		t.Fatalf("fetch got %v", nodes)
+	}
+
+	if err = m.Deregister(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if nodes, _ = m.Fetch(ctx); len(nodes) != 0 {
+		t.Fatalf("expired nodes fetched %v", nodes)
+	}
+
+	m.Close()
+	if _, err = m.Fetch(ctx); err != ErrClosed {
+		t.Fatalf("fetch closed registry - (%v)", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	m := NewMemory(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := Watch(ctx, m, 10*time.Millisecond)
+	if nodes := <-ch; len(nodes) != 0 {
+		t.Fatalf("watch got %v", nodes)
+	}
+
+	m.Register(ctx, &Node{Server: "a", Online: 1})
+	if nodes := <-ch; len(nodes) != 1 || nodes[0].Online != 1 {
+		t.Fatalf("watch got %v", nodes)
+	}
+
+	// renewing without change is not sent
+	m.Register(ctx, &Node{Server: "a", Online: 1})
+	m.Register(ctx, &Node{Server: "a", Online: 2})
+	if nodes := <-ch; len(nodes) != 1 || nodes[0].Online != 2 {
+		t.Fatalf("watch got %v", nodes)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
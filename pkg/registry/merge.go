@@ -0,0 +1,48 @@
+package registry
+
+// Client is the connection to a server of the nodes
+type Client interface {
+	comparable
+	Addr() string
+	Close() error
+}
+
+// Merge returns the clients by server of the nodes and the static servers, a static server is skipped
+// if a node registers the same rpc addr. The clients of old are reused while their server keeps the addr,
+// the others are dialed, a server failed to dial is skipped. The clients of old not reused are returned
+// as left, they should be closed once the clients replace old
+func Merge[C Client](old map[string]C, nodes []*Node, static map[string]string, dial func(server, addr string) (C, error)) (clients map[string]C, left []C) {
+	clients = make(map[string]C, len(nodes)+len(static))
+	addrs := make(map[string]bool, len(nodes))
+
+	add := func(server, addr string) {
+		if c, ok := old[server]; ok && c.Addr() == addr {
+			clients[server] = c
+			return
+		}
+		if c, err := dial(server, addr); err == nil {
+			clients[server] = c
+		}
+	}
+
+	for _, n := range nodes {
+		if n.RPCAddr == "" {
+			continue
+		}
+		addrs[n.RPCAddr] = true
+		add(n.Server, n.RPCAddr)
+	}
+	for server, addr := range static {
+		if _, ok := clients[server]; ok || addrs[addr] {
+			continue
+		}
+		add(server, addr)
+	}
+
+	for server, c := range old {
+		if clients[server] != c {
+			left = append(left, c)
+		}
+	}
+	return
+}
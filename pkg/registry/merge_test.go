@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+)
+
+type testClient struct {
+	server string
+	addr   string
+}
+
+func (c *testClient) Addr() string {
+	return c.addr
+}
+
+func (c *testClient) Close() error {
+	return nil
+}
+
+func TestMerge(t *testing.T) {
+	var dialed []string
+	dial := func(server, addr string) (*testClient, error) {
+		if addr == "bad" {
+			return nil, errors.New("dial error")
+		}
+		dialed = append(dialed, server)
+		return &testClient{server: server, addr: addr}, nil
+	}
+
+	static := map[string]string{"localhost": "127.0.0.1:3109", "s3": "127.0.0.1:3309"}
+	old, left := Merge(nil, nil, static, dial)
+	if len(old) != 2 || len(left) != 0 {
+		t.Fatalf("merge static got %v left %v", old, left)
+	}
+
+	dialed = nil
+	nodes := []*Node{
+		{Server: "host1", RPCAddr: "127.0.0.1:3109"},
+		{Server: "s3", RPCAddr: "127.0.0.1:3309"},
+		{Server: "s4", RPCAddr: "bad"},
+		{Server: "s5"},
+	}
+	clients, left := Merge(old, nodes, static, dial)
+	if len(clients) != 2 || clients["host1"] == nil || clients["s3"] != old["s3"] {
+		t.Fatalf("merge nodes got %v", clients)
+	}
+	if len(dialed) != 1 || dialed[0] != "host1" {
+		t.Fatalf("dialed %v", dialed)
+	}
+	if len(left) != 1 || left[0] != old["localhost"] {
+		t.Fatalf("static server of the same addr not left %v", left)
+	}
+
+	clients, left = Merge(clients, nil, static, dial)
+	if len(clients) != 2 || clients["localhost"] == nil || len(left) != 1 || left[0].server != "host1" {
+		t.Fatalf("merge without nodes got %v left %v", clients, left)
+	}
+}
@@ -0,0 +1,134 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// Redis keeps every node in a key expiring after the ttl and the servers of the app in a set
+type Redis struct {
+	pool *redis.Pool
+	app  string
+	ttl  int
+}
+
+// NewRedisPool returns the pool of the redis server the nodes are registered in
+func NewRedisPool(network, addr, auth string, timeout time.Duration) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     2,
+		IdleTimeout: time.Minute,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial(network, addr,
+				redis.DialPassword(auth),
+				redis.DialConnectTimeout(timeout),
+				redis.DialReadTimeout(timeout),
+				redis.DialWriteTimeout(timeout),
+			)
+		},
+	}
+}
+
+func NewRedis(pool *redis.Pool, app string, ttl time.Duration) *Redis {
+	r := &Redis{pool: pool, app: app, ttl: int(ttl / time.Second)}
+	if r.ttl <= 0 {
+		r.ttl = 1
+	}
+	return r
+}
+
+func (r *Redis) keyNodes() string {
+	return fmt.Sprintf("nodes:%s", r.app)
+}
+
+func (r *Redis) keyNode(server string) string {
+	return fmt.Sprintf("node:%s:%s", r.app, server)
+}
+
+func (r *Redis) Register(ctx context.Context, n *Node) error {
+	node := *n
+	node.Updated = time.Now().UnixNano()
+	b, err := json.Marshal(&node)
+	if err != nil {
+		return err
+	}
+
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.Send("SET", r.keyNode(n.Server), b, "EX", r.ttl); err != nil {
+		return err
+	}
+	_, err = conn.Do("SADD", r.keyNodes(), n.Server)
+	return err
+}
+
+func (r *Redis) Deregister(ctx context.Context, server string) error {
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.Send("DEL", r.keyNode(server)); err != nil {
+		return err
+	}
+	_, err = conn.Do("SREM", r.keyNodes(), server)
+	return err
+}
+
+// Fetch returns the live nodes, the servers whose node is expired are removed from the set
+func (r *Redis) Fetch(ctx context.Context) ([]*Node, error) {
+	conn, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	servers, err := redis.Strings(conn.Do("SMEMBERS", r.keyNodes()))
+	if err != nil || len(servers) == 0 {
+		return []*Node{}, err
+	}
+
+	args := make([]interface{}, 0, len(servers))
+	for _, server := range servers {
+		args = append(args, r.keyNode(server))
+	}
+	values, err := redis.ByteSlices(conn.Do("MGET", args...))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nodes   = make([]*Node, 0, len(servers))
+		expired []interface{}
+	)
+	for i, v := range values {
+		if v == nil {
+			expired = append(expired, servers[i])
+			continue
+		}
+		n := new(Node)
+		if err = json.Unmarshal(v, n); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+
+	if len(expired) > 0 {
+		if _, err = conn.Do("SREM", append([]interface{}{r.keyNodes()}, expired...)...); err != nil {
+			return nil, err
+		}
+	}
+	sortNodes(nodes)
+	return nodes, nil
+}
+
+func (r *Redis) Close() error {
+	return r.pool.Close()
+}
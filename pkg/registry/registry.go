@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	AppCat       = "cat"
+	AppScratcher = "scratcher"
+)
+
+var (
+	ErrClosed = errors.New("registry: closed")
+)
+
+// Node is a server instance, it's dropped if not registered again within the ttl
+type Node struct {
+	Server  string   `json:"server"`
+	Region  string   `json:"region"`
+	Zone    string   `json:"zone"`
+	RPCAddr string   `json:"rpc_addr"`
+	Addrs   []string `json:"addrs"` //addresses the clients connect to
+	Weight  float64  `json:"weight"`
+	Online  int32    `json:"online"` //live connections
+	Updated int64    `json:"updated"`
+}
+
+// Registry keeps the live nodes of an app
+type Registry interface {
+	// Register adds or renews the node
+	Register(ctx context.Context, n *Node) error
+	Deregister(ctx context.Context, server string) error
+	// Fetch returns the live nodes sorted by server
+	Fetch(ctx context.Context) ([]*Node, error)
+	Close() error
+}
+
+// Watch fetches the nodes every interval, the channel receives them whenever they change
+// and is closed when ctx is done or the registry is closed
+func Watch(ctx context.Context, r Registry, interval time.Duration) <-chan []*Node {
+	ch := make(chan []*Node, 1)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last []*Node
+		for {
+			nodes, err := r.Fetch(ctx)
+			if err == ErrClosed {
+				return
+			}
+			if err == nil && (last == nil || changed(last, nodes)) {
+				last = nodes
+				select {
+				case ch <- nodes:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// changed compares the nodes ignoring the time they are renewed
+func changed(a, b []*Node) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for i := range a {
+		x, y := *a[i], *b[i]
+		x.Updated, y.Updated = 0, 0
+		if !reflect.DeepEqual(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Server < nodes[j].Server
+	})
+}
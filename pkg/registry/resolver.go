@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"context"
+	"google.golang.org/grpc/resolver"
+	"time"
+)
+
+const (
+	Scheme = "registry"
+)
+
+// Builder resolves the grpc target "registry:///<app>" into the static addresses
+// and the rpc addresses of the nodes registered, r may be nil for the static addresses only
+type Builder struct {
+	static   []string
+	r        Registry
+	interval time.Duration
+}
+
+func NewBuilder(static []string, r Registry, interval time.Duration) *Builder {
+	return &Builder{static: static, r: r, interval: interval}
+}
+
+// Target returns the grpc target of the app
+func Target(app string) string {
+	return Scheme + ":///" + app
+}
+
+func (b *Builder) Scheme() string {
+	return Scheme
+}
+
+func (b *Builder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &nodeResolver{cc: cc, static: b.static, cancel: cancel}
+
+	if b.r == nil {
+		return r, r.update(nil)
+	}
+	ch := Watch(ctx, b.r, b.interval)
+	go func() {
+		for nodes := range ch {
+			if err := r.update(nodes); err != nil {
+				cc.ReportError(err)
+			}
+		}
+	}()
+	return r, nil
+}
+
+type nodeResolver struct {
+	cc     resolver.ClientConn
+	static []string
+	cancel context.CancelFunc
+}
+
+func (r *nodeResolver) update(nodes []*Node) error {
+	var (
+		seen  = make(map[string]struct{})
+		addrs = make([]resolver.Address, 0, len(r.static)+len(nodes))
+	)
+	add := func(addr string) {
+		if _, ok := seen[addr]; ok || addr == "" {
+			return
+		}
+		seen[addr] = struct{}{}
+		addrs = append(addrs, resolver.Address{Addr: addr})
+	}
+	for _, addr := range r.static {
+		add(addr)
+	}
+	for _, n := range nodes {
+		add(n.RPCAddr)
+	}
+	return r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+func (r *nodeResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *nodeResolver) Close() {
+	r.cancel()
+}
@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"context"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+	"testing"
+	"time"
+)
+
+type testClientConn struct {
+	states chan resolver.State
+}
+
+func (t *testClientConn) UpdateState(s resolver.State) error {
+	t.states <- s
+	return nil
+}
+
+func (t *testClientConn) ReportError(error) {}
+
+func (t *testClientConn) NewAddress(addresses []resolver.Address) {}
+
+func (t *testClientConn) NewServiceConfig(serviceConfig string) {}
+
+func (t *testClientConn) ParseServiceConfig(serviceConfigJSON string) *serviceconfig.ParseResult {
+	return nil
+}
+
+func TestResolver(t *testing.T) {
+	m := NewMemory(time.Second)
+	m.Register(context.Background(), &Node{Server: "b", RPCAddr: "10.0.0.2:3119"})
+	m.Register(context.Background(), &Node{Server: "a", RPCAddr: "10.0.0.1:3119"})
+
+	cc := &testClientConn{states: make(chan resolver.State, 1)}
+	r, err := NewBuilder([]string{"10.0.0.1:3119"}, m, 10*time.Millisecond).Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	s := <-cc.states
+	if len(s.Addresses) != 2 || s.Addresses[0].Addr != "10.0.0.1:3119" || s.Addresses[1].Addr != "10.0.0.2:3119" {
+		t.Fatalf("resolved %v", s.Addresses)
+	}
+
+	m.Deregister(context.Background(), "b")
+	if s = <-cc.states; len(s.Addresses) != 1 || s.Addresses[0].Addr != "10.0.0.1:3119" {
+		t.Fatalf("resolved %v", s.Addresses)
+	}
+}
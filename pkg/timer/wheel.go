@@ -0,0 +1,140 @@
+package timer
+
+import (
+	"sync"
+	"time"
+)
+
+// Timer is a task of the wheel, it is never expired early and at most one tick late
+type Timer struct {
+	fn     func()
+	slot   int
+	rounds int
+	prev   *Timer
+	next   *Timer
+}
+
+// Wheel is a hashed timing wheel, adding, resetting and deleting a timer is O(1)
+// and all the timers are driven by one ticker
+type Wheel struct {
+	lock  sync.Mutex
+	tick  time.Duration
+	slots []*Timer
+	pos   int
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func NewWheel(tick time.Duration, size int) *Wheel {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	if size <= 0 {
+		size = 1
+	}
+	w := &Wheel{
+		tick:  tick,
+		slots: make([]*Timer, size),
+		stop:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Add calls fn in the wheel goroutine after d
+func (w *Wheel) Add(d time.Duration, fn func()) *Timer {
+	t := &Timer{fn: fn}
+	w.lock.Lock()
+	w.add(t, d)
+	w.lock.Unlock()
+	return t
+}
+
+// Set resets the timer to expire after d, an expired or deleted timer is added again
+func (w *Wheel) Set(t *Timer, d time.Duration) {
+	w.lock.Lock()
+	w.del(t)
+	w.add(t, d)
+	w.lock.Unlock()
+}
+
+// Del deletes the timer, it's a no-op if the timer is expired or deleted
+func (w *Wheel) Del(t *Timer) {
+	w.lock.Lock()
+	w.del(t)
+	w.lock.Unlock()
+}
+
+// Stop stops the wheel, the pending timers are never expired
+func (w *Wheel) Stop() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (w *Wheel) add(t *Timer, d time.Duration) {
+	// the next advance may come right away, so one more tick keeps the timer from expiring early
+	ticks := int((d+w.tick-1)/w.tick) + 1
+	if ticks < 1 {
+		ticks = 1
+	}
+	t.slot = (w.pos + ticks) % len(w.slots)
+	t.rounds = (ticks - 1) / len(w.slots)
+
+	head := w.slots[t.slot]
+	t.prev, t.next = nil, head
+	if head != nil {
+		head.prev = t
+	}
+	w.slots[t.slot] = t
+}
+
+func (w *Wheel) del(t *Timer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else if w.slots[t.slot] == t {
+		w.slots[t.slot] = t.next
+	} else {
+		// not in the wheel
+		return
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+	t.prev, t.next = nil, nil
+}
+
+func (w *Wheel) run() {
+	ticker := time.NewTicker(w.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, t := range w.advance() {
+				t.fn()
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// advance moves to the next slot and removes its expired timers
+func (w *Wheel) advance() (expired []*Timer) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.pos = (w.pos + 1) % len(w.slots)
+	for t := w.slots[w.pos]; t != nil; {
+		next := t.next
+		if t.rounds > 0 {
+			t.rounds--
+		} else {
+			w.del(t)
+			expired = append(expired, t)
+		}
+		t = next
+	}
+	return
+}
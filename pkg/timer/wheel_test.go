@@ -0,0 +1,62 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWheelExpire(t *testing.T) {
+	w := NewWheel(10*time.Millisecond, 4)
+	defer w.Stop()
+
+	start := time.Now()
+	done := make(chan time.Duration, 1)
+	w.Add(100*time.Millisecond, func() {
+		done <- time.Since(start)
+	})
+
+	select {
+	case d := <-done:
+		if d < 100*time.Millisecond {
+			t.Fatalf("expired too early after %v", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timer not expired")
+	}
+}
+
+func TestWheelSetDel(t *testing.T) {
+	w := NewWheel(10*time.Millisecond, 8)
+	defer w.Stop()
+
+	fired := make(chan struct{}, 2)
+	deleted := w.Add(30*time.Millisecond, func() {
+		fired <- struct{}{}
+	})
+	w.Del(deleted)
+	w.Del(deleted)
+
+	start := time.Now()
+	reset := w.Add(30*time.Millisecond, func() {
+		fired <- struct{}{}
+	})
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		w.Set(reset, 50*time.Millisecond)
+	}
+
+	select {
+	case <-fired:
+		if d := time.Since(start); d < 150*time.Millisecond {
+			t.Fatalf("reset timer expired after %v", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reset timer not expired")
+	}
+
+	select {
+	case <-fired:
+		t.Fatal("deleted timer expired")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
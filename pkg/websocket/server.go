@@ -9,9 +9,12 @@ import (
 	"errors"
 	"fmt"
 	log "github.com/golang/glog"
+	"io"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
@@ -31,7 +34,23 @@ const (
 	CloseMessage  = 8
 )
 
+// close codes, 4000-4999 are left to the application
+const (
+	CloseNormalClosure     = 1000
+	CloseGoingAway         = 1001
+	CloseProtocolError     = 1002
+	CloseInternalServerErr = 1011
+)
+
+const (
+	maxControlPayload = 125
+	maxCloseReason    = maxControlPayload - 2
+)
+
+// Conn is a websocket connection, a frame written by Header, WriteBody and Flush must hold
+// the lock if Write, WriteClose or Read may be called concurrently
 type Conn struct {
+	sync.Mutex
 	Request *Request
 	conn    net.Conn
 	reader  *bufio.Reader
@@ -149,12 +168,11 @@ func (w *Websocket) Close() {
 
 func NewConn(w *Websocket) *Conn {
 	return &Conn{
-		w.Request,
-		w.conn,
-		w.reader,
-		w.writer,
-		make([]byte, 1024),
-		0,
+		Request: w.Request,
+		conn:    w.conn,
+		reader:  w.reader,
+		writer:  w.writer,
+		buf:     make([]byte, 1024),
 	}
 }
 
@@ -166,7 +184,7 @@ func (c *Conn) Peek(n int64) []byte {
 
 func (c *Conn) Buffer() []byte {
 	defer func() {
-		c.buf = c.buf[:0]
+		c.n = 0
 	}()
 	return c.buf[:c.n]
 }
@@ -203,14 +221,14 @@ func (c *Conn) WriteBody(b []byte) error {
 	return nil
 }
 
-func (c *Conn) Flush() {
-	c.writer.Flush()
+func (c *Conn) Flush() error {
+	return c.writer.Flush()
 }
 
 func (c *Conn) ReadFrame() (fin bool, op int, payload []byte, err error) {
 
 	b := make([]byte, 2)
-	_, err = c.reader.Read(b)
+	_, err = io.ReadFull(c.reader, b)
 	if err != nil {
 		return
 	}
@@ -233,14 +251,14 @@ func (c *Conn) ReadFrame() (fin bool, op int, payload []byte, err error) {
 	switch int8(b[1] & 0x7F) {
 	case 126:
 		b = make([]byte, 2)
-		_, err = c.reader.Read(b)
+		_, err = io.ReadFull(c.reader, b)
 		if err != nil {
 			return
 		}
-		payloadLen = binary.BigEndian.Uint64(b)
+		payloadLen = uint64(binary.BigEndian.Uint16(b))
 	case 127:
 		b = make([]byte, 8)
-		_, err = c.reader.Read(b)
+		_, err = io.ReadFull(c.reader, b)
 		if err != nil {
 			return
 		}
@@ -251,7 +269,7 @@ func (c *Conn) ReadFrame() (fin bool, op int, payload []byte, err error) {
 
 	if mask {
 		maskKey = make([]byte, 4)
-		_, err = c.reader.Read(maskKey)
+		_, err = io.ReadFull(c.reader, maskKey)
 		if err != nil {
 			return
 		}
@@ -259,7 +277,7 @@ func (c *Conn) ReadFrame() (fin bool, op int, payload []byte, err error) {
 
 	if payloadLen > 0 {
 		payload = make([]byte, payloadLen)
-		_, err = c.reader.Read(payload)
+		_, err = io.ReadFull(c.reader, payload)
 		if err != nil {
 			return
 		}
@@ -275,14 +293,30 @@ func (c *Conn) ReadFrame() (fin bool, op int, payload []byte, err error) {
 	return
 }
 
+// Write writes b in one frame
 func (c *Conn) Write(op int, b []byte) error {
-	//buf := make([]byte, 2*1024)
-	//c.Header(op, uint64(len(b)), buf)
-	//copy(buf[len(buf):], b)
-	//if err := c.WriteBody(buf); err != nil {
-	//	return err
-	//}
-	return nil
+	c.Lock()
+	defer c.Unlock()
+
+	c.Header(op, uint64(len(b)))
+	if err := c.WriteBody(c.Buffer()); err != nil {
+		return err
+	}
+	if err := c.WriteBody(b); err != nil {
+		return err
+	}
+	return c.Flush()
+}
+
+// WriteClose writes the close frame, the reason is truncated to fit the control frame
+func (c *Conn) WriteClose(code int, reason string) error {
+	if len(reason) > maxCloseReason {
+		reason = reason[:maxCloseReason]
+	}
+	b := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(b, uint16(code))
+	copy(b[2:], reason)
+	return c.Write(CloseMessage, b)
 }
 
 func (c *Conn) Read() (op int, payload []byte, err error) {
@@ -297,6 +331,12 @@ func (c *Conn) Read() (op int, payload []byte, err error) {
 
 		switch op {
 		case CloseMessage:
+			// echo the close code to complete the closing handshake
+			code := CloseNormalClosure
+			if len(p) >= 2 {
+				code = int(binary.BigEndian.Uint16(p))
+			}
+			c.WriteClose(code, "")
 			err = ErrMessageClose
 			return
 		case PingMessage:
@@ -320,6 +360,11 @@ func (c *Conn) Read() (op int, payload []byte, err error) {
 	}
 }
 
+// SetWriteDeadline bounds the pending and future writes, a zero t means no deadline
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
 func (c *Conn) Close() {
 	c.conn.Close()
 }
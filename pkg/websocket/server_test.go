@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"bufio"
+	"bytes"
 	"testing"
 )
 
@@ -11,3 +13,79 @@ func TestServer(t *testing.T) {
 	b[0] = 'j'
 	println(string(a))
 }
+
+func TestConnWriteFrames(t *testing.T) {
+	var out bytes.Buffer
+	c := &Conn{writer: bufio.NewWriter(&out), buf: make([]byte, 1024)}
+
+	for _, body := range []string{"a", "bc"} {
+		c.Header(BinaryMessage, uint64(len(body)))
+		if err := c.WriteBody(c.Buffer()); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.WriteBody([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []byte{0x82, 1, 'a', 0x82, 2, 'b', 'c'}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("frames got %v want %v", out.Bytes(), want)
+	}
+}
+
+func TestConnWriteClose(t *testing.T) {
+	var out bytes.Buffer
+	c := &Conn{writer: bufio.NewWriter(&out), buf: make([]byte, 1024)}
+
+	if err := c.WriteClose(4001, "bad token"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := append([]byte{0x88, 11, 0x0f, 0xa1}, "bad token"...)
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("close frame got %v want %v", out.Bytes(), want)
+	}
+
+	out.Reset()
+	if err := c.WriteClose(4001, string(make([]byte, 200))); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 2+maxControlPayload {
+		t.Fatalf("close frame length %d", out.Len())
+	}
+}
+
+func TestConnRead(t *testing.T) {
+	payload := bytes.Repeat([]byte{'x'}, 200)
+	mask := []byte{1, 2, 3, 4}
+	masked := make([]byte, len(payload))
+	for i := range payload {
+		masked[i] = payload[i] ^ mask[i%4]
+	}
+
+	in := append([]byte{0x82, 0x80 | 126, 0, 200}, mask...)
+	in = append(in, masked...)
+	in = append(in, 0x88, 2, 0x03, 0xe8)
+
+	var out bytes.Buffer
+	c := &Conn{reader: bufio.NewReader(bytes.NewReader(in)), writer: bufio.NewWriter(&out), buf: make([]byte, 1024)}
+
+	op, p, err := c.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op != BinaryMessage || !bytes.Equal(p, payload) {
+		t.Fatalf("read op %d payload %d bytes", op, len(p))
+	}
+
+	if _, _, err = c.Read(); err != ErrMessageClose {
+		t.Fatalf("read close frame - (%v)", err)
+	}
+	if want := []byte{0x88, 2, 0x03, 0xe8}; !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("close echo got %v want %v", out.Bytes(), want)
+	}
+}